@@ -2,38 +2,177 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 
+	"github.com/popescu-af/saas-y/internal/engine"
 	"github.com/popescu-af/saas-y/internal/engine/golang"
+	"github.com/popescu-af/saas-y/internal/model"
+	"github.com/popescu-af/saas-y/internal/parser"
 )
 
+const usage = `usage: saas-y <command> [flags]
+
+commands:
+  init      write a starter spec
+  validate  check a spec
+  list      print the services of a spec, their endpoints and dependencies
+  generate  generate the code and deployment files of the services of a spec
+
+Run 'saas-y <command> -h' for the flags of a command.
+Without a command, saas-y generates.
+`
+
 func main() {
-	inputFilePath, outputDirPath := parseArgs()
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") && !isHelp(os.Args[1]) {
+		generate(os.Args[1:])
+		return
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "init":
+		initSpec(args)
+	case "validate":
+		validate(args)
+	case "list":
+		list(args)
+	case "generate":
+		generate(args)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		if !isHelp(command) {
+			os.Exit(2)
+		}
+	}
+}
+
+// fail prints the error and exits, listing the problems of an invalid spec one per line.
+func fail(err error) {
+	if errs, ok := err.(model.ValidationErrors); ok {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		log.Fatalf("error: invalid spec, %d problem(s) found", len(errs))
+	}
+	log.Fatalf("error: %v", err)
+}
+
+func isHelp(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "-help" || arg == "--help"
+}
 
-	stat, err := os.Stat(inputFilePath)
+// input holds the flags describing the input file of the commands reading a spec.
+type input struct {
+	filePath  string
+	isOpenAPI bool
+	openAPI   parser.OpenAPI
+}
+
+func (in *input) register(flags *flag.FlagSet) {
+	flags.StringVar(&in.filePath, "input", "./spec.json", "path to the saas-y specification, either JSON or YAML (.json, .yaml or .yml)")
+	flags.BoolVar(&in.isOpenAPI, "openapi", false, "import the input as an OpenAPI 3 JSON document describing a single service")
+	flags.StringVar(&in.openAPI.RepositoryURL, "repository-url", "", "repository URL of the imported OpenAPI service's SaaS")
+	flags.StringVar(&in.openAPI.ServiceName, "service-name", "", "name of the imported OpenAPI service, defaults to the document title")
+	flags.StringVar(&in.openAPI.Port, "port", "80", "port of the imported OpenAPI service")
+}
+
+// parser checks the input file and returns the parser reading it.
+func (in *input) parser() parser.Abstract {
+	stat, err := os.Stat(in.filePath)
 	if os.IsNotExist(err) {
-		log.Fatalln("file does not exist - " + inputFilePath)
+		log.Fatalln("file does not exist - " + in.filePath)
 	}
 
 	if stat.IsDir() {
-		log.Fatalln("input is a directory - " + inputFilePath)
+		log.Fatalln("input is a directory - " + in.filePath)
 	}
 
-	stat, err = os.Stat(outputDirPath)
-	if err == nil && !stat.IsDir() {
-		log.Fatalln("output is not a directory - " + outputDirPath)
+	if in.isOpenAPI {
+		return &in.openAPI
 	}
 
-	err = golang.GenerateSourcesFromJSONSpec(inputFilePath, outputDirPath)
+	p, err := parser.ForFile(in.filePath)
 	if err != nil {
-		log.Fatalf("error: %v", err)
+		fail(err)
 	}
+	return p
 }
 
-func parseArgs() (inputFilePath string, outputDirPath string) {
-	flag.StringVar(&inputFilePath, "input", "./spec.json", "path to the saas-y specification JSON")
-	flag.StringVar(&outputDirPath, "output", "./output-saas-y", "path to the output directory")
-	flag.Parse()
-	return
+func initSpec(args []string) {
+	flags := flag.NewFlagSet("init", flag.ExitOnError)
+	outputFilePath := flags.String("output", "./spec.json", "path of the spec to write")
+	repositoryURL := flags.String("repository-url", "", "URL of the repository to generate the services in, e.g. github.com/user/repository")
+	flags.Parse(args)
+
+	if *repositoryURL == "" {
+		log.Fatalln("missing repository URL, please provide one with -repository-url")
+	}
+
+	if _, err := os.Stat(*outputFilePath); err == nil {
+		log.Fatalln("file already exists - " + *outputFilePath)
+	}
+
+	spec, err := engine.StarterSpec(*repositoryURL)
+	if err != nil {
+		fail(err)
+	}
+
+	if err = ioutil.WriteFile(*outputFilePath, spec, 0660); err != nil {
+		fail(err)
+	}
+}
+
+func validate(args []string) {
+	var in input
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	in.register(flags)
+	flags.Parse(args)
+
+	if _, err := engine.ParseSpec(in.parser(), in.filePath); err != nil {
+		fail(err)
+	}
+	fmt.Println(in.filePath + " is valid")
+}
+
+func list(args []string) {
+	var in input
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	in.register(flags)
+	flags.Parse(args)
+
+	spec, err := engine.ParseSpec(in.parser(), in.filePath)
+	if err != nil {
+		fail(err)
+	}
+	engine.List(spec, os.Stdout)
+}
+
+func generate(args []string) {
+	var in input
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	in.register(flags)
+	outputDirPath := flags.String("output", "./output-saas-y", "path to the output directory")
+	dryRun := flags.Bool("dry-run", false, "generate nothing, only list the files that would be created, changed or left unchanged")
+	diff := flags.Bool("diff", false, "like -dry-run, also printing the unified diffs against the existing output")
+	flags.Parse(args)
+
+	p := in.parser()
+
+	stat, err := os.Stat(*outputDirPath)
+	if err == nil && !stat.IsDir() {
+		log.Fatalln("output is not a directory - " + *outputDirPath)
+	}
+
+	if *dryRun || *diff {
+		err = golang.PreviewSources(p, in.filePath, *outputDirPath, *diff, os.Stdout)
+	} else {
+		err = golang.GenerateSources(p, in.filePath, *outputDirPath)
+	}
+	if err != nil {
+		fail(err)
+	}
 }
@@ -1,6 +1,6 @@
 module github.com/popescu-af/saas-y
 
-go 1.12
+go 1.16
 
 require (
 	github.com/go-redis/redis/v8 v8.0.0-beta.7
@@ -9,4 +9,5 @@ require (
 	github.com/prometheus/client_golang v1.9.0 // indirect
 	github.com/stretchr/testify v1.6.1
 	go.uber.org/zap v1.15.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,180 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around the changes.
+const context = 3
+
+// Unified returns the unified diff turning a into b, with the given file names
+// in its header, or an empty string if the contents are equal.
+func Unified(aName, bName string, a, b []byte) string {
+	aLines, bLines := lines(a), lines(b)
+	edits := compare(aLines, bLines)
+
+	var out strings.Builder
+	for start := 0; start < len(edits); {
+		// find the next change and the extent of its hunk
+		for start < len(edits) && edits[start].op == equal {
+			start++
+		}
+		if start == len(edits) {
+			break
+		}
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+		}
+
+		first := max(start-context, 0)
+		end, unchanged := start, 0
+		for end < len(edits) && unchanged <= 2*context {
+			if edits[end].op == equal {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+			end++
+		}
+		last := min(end-unchanged+context, len(edits))
+
+		hunk := edits[first:last]
+		aStart, bStart := edits[first].a, edits[first].b
+		aCount, bCount := 0, 0
+		for _, e := range hunk {
+			if e.op != insert {
+				aCount++
+			}
+			if e.op != remove {
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		for _, e := range hunk {
+			switch e.op {
+			case equal:
+				out.WriteString(" " + aLines[e.a])
+			case remove:
+				out.WriteString("-" + aLines[e.a])
+			case insert:
+				out.WriteString("+" + bLines[e.b])
+			}
+			if e.op == insert && !strings.HasSuffix(bLines[e.b], "\n") || e.op != insert && !strings.HasSuffix(aLines[e.a], "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = last
+	}
+	return out.String()
+}
+
+// hunkRange formats the 1-based range of a hunk, which starts after line start if empty.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// lines splits a text into lines, keeping their line endings.
+func lines(text []byte) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	result := strings.SplitAfter(string(text), "\n")
+	if result[len(result)-1] == "" {
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+type operation int
+
+const (
+	equal operation = iota
+	remove
+	insert
+)
+
+// edit is a step of the script turning a into b, at the given line indices.
+type edit struct {
+	op   operation
+	a, b int
+}
+
+// compare returns the shortest edit script turning a into b, found through
+// their longest common subsequence once their common ends are set aside.
+func compare(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []edit
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{equal, i, i})
+	}
+
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of am[i:] and bm[j:]
+	lcs := make([][]int32, len(am)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(bm)+1)
+	}
+	for i := len(am) - 1; i >= 0; i-- {
+		for j := len(bm) - 1; j >= 0; j-- {
+			if am[i] == bm[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(am) || j < len(bm) {
+		switch {
+		case i < len(am) && j < len(bm) && am[i] == bm[j]:
+			edits = append(edits, edit{equal, prefix + i, prefix + j})
+			i++
+			j++
+		case j == len(bm) || i < len(am) && lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{remove, prefix + i, prefix + j})
+			i++
+		default:
+			edits = append(edits, edit{insert, prefix + i, prefix + j})
+			j++
+		}
+	}
+
+	for k := 0; k < suffix; k++ {
+		edits = append(edits, edit{equal, len(a) - suffix + k, len(b) - suffix + k})
+	}
+	return edits
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
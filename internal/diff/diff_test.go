@@ -0,0 +1,54 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/internal/diff"
+)
+
+func numbered(from, to int, changes map[int]string) []byte {
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		if c, ok := changes[i]; ok {
+			b.WriteString(c)
+			continue
+		}
+		b.WriteString(strings.Repeat("x", i%3+1) + " " + string(rune('a'+i%26)) + "\n")
+	}
+	return []byte(b.String())
+}
+
+func TestUnified(t *testing.T) {
+	require.Empty(t, diff.Unified("a", "b", []byte("same\n"), []byte("same\n")))
+
+	require.Equal(t, "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+one\n+two\n", diff.Unified("a", "b", nil, []byte("one\ntwo\n")))
+	require.Equal(t, "--- a\n+++ b\n@@ -1 +0,0 @@\n-one\n", diff.Unified("a", "b", []byte("one\n"), nil))
+	require.Equal(t, "--- a\n+++ b\n@@ -1 +1 @@\n-one\n\\ No newline at end of file\n+one\n", diff.Unified("a", "b", []byte("one"), []byte("one\n")))
+
+	a := numbered(1, 20, nil)
+	b := numbered(1, 20, map[int]string{2: "changed\n", 18: ""})
+	require.Equal(t, `--- a/f
++++ b/f
+@@ -1,5 +1,5 @@
+ xx b
+-xxx c
++changed
+ x d
+ xx e
+ xxx f
+@@ -15,6 +15,5 @@
+ x p
+ xx q
+ xxx r
+-x s
+ xx t
+ xxx u
+`, diff.Unified("a/f", "b/f", a, b))
+
+	// close changes share a hunk
+	b = numbered(1, 20, map[int]string{5: "five\n", 11: "eleven\n"})
+	require.Equal(t, 1, strings.Count(diff.Unified("a/f", "b/f", a, b), "@@ -"))
+}
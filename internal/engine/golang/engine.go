@@ -1,28 +1,134 @@
 package golang
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/popescu-af/saas-y/internal/diff"
+	"github.com/popescu-af/saas-y/internal/engine"
 	"github.com/popescu-af/saas-y/internal/generator"
 	gengo "github.com/popescu-af/saas-y/internal/generator/go"
+	"github.com/popescu-af/saas-y/internal/model"
 	"github.com/popescu-af/saas-y/internal/parser"
 )
 
-// GenerateSourcesFromJSONSpec generates go code for the services from
-// a JSON specification, saving it under the specified path.
-func GenerateSourcesFromJSONSpec(jsonSpecFilePath, outdir string) (err error) {
-	generator.Init()
+// GenerateSourcesFromSpec generates go code for the services from a JSON
+// or YAML specification, saving it under the specified path.
+func GenerateSourcesFromSpec(specFilePath, outdir string) (err error) {
+	p, err := parser.ForFile(specFilePath)
+	if err != nil {
+		return
+	}
+	return GenerateSources(p, specFilePath, outdir)
+}
+
+// GenerateSources generates go code for the services from an input
+// file of any format, read by the given parser.
+func GenerateSources(p parser.Abstract, inputFilePath, outdir string) (err error) {
+	spec, err := parseSpec(p, inputFilePath)
+	if err != nil {
+		return
+	}
 
-	p := &parser.JSON{}
-	spec, err := p.Parse(jsonSpecFilePath)
+	g := &gengo.Generator{}
+	if err = generator.Do(g, spec, outdir); err != nil {
+		return
+	}
+	fmt.Println("Done.")
+	return
+}
+
+// PreviewSources generates the sources like GenerateSources, but leaves the output directory
+// untouched. It prints which files would be created, changed or left unchanged and, if
+// showDiff is set, the unified diffs of the created and changed files. The warnings about
+// the merges of the hand-written files are printed to stderr, as when generating.
+// The sources are generated into a temporary directory, which the formatting tools need,
+// holding a copy of the preserved files of the output directory for them to be merged into.
+func PreviewSources(p parser.Abstract, inputFilePath, outdir string, showDiff bool, w io.Writer) (err error) {
+	spec, err := parseSpec(p, inputFilePath)
 	if err != nil {
 		return
 	}
 
-	err = spec.Validate()
+	stagingDir, err := ioutil.TempDir("", "saas-y-preview")
 	if err != nil {
 		return
 	}
+	defer os.RemoveAll(stagingDir)
 
 	g := &gengo.Generator{}
-	err = generator.Do(g, spec, outdir)
+	for _, f := range generator.PreservedFiles(g, spec) {
+		if err = copyIfExists(path.Join(outdir, f), path.Join(stagingDir, f)); err != nil {
+			return
+		}
+	}
+
+	if err = generator.Do(g, spec, stagingDir); err != nil {
+		return
+	}
+
+	var diffs []string
+	err = filepath.Walk(stagingDir, func(staged string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(stagingDir, staged)
+		if err != nil {
+			return err
+		}
+
+		generated, err := ioutil.ReadFile(staged)
+		if err != nil {
+			return err
+		}
+
+		status, existingName := "changed", "a/"+rel
+		existing, err := ioutil.ReadFile(filepath.Join(outdir, rel))
+		if os.IsNotExist(err) {
+			status, existingName = "created", "/dev/null"
+		} else if err != nil {
+			return err
+		} else if string(existing) == string(generated) {
+			status = "unchanged"
+		}
+		fmt.Fprintf(w, "%-9s %s\n", status, rel)
+
+		if showDiff && status != "unchanged" {
+			diffs = append(diffs, diff.Unified(existingName, "b/"+rel, existing, generated))
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	for _, d := range diffs {
+		fmt.Fprint(w, "\n"+d)
+	}
 	return
 }
+
+func parseSpec(p parser.Abstract, inputFilePath string) (*model.Spec, error) {
+	generator.Init()
+	return engine.ParseSpec(p, inputFilePath)
+}
+
+func copyIfExists(src, dst string) error {
+	b, err := ioutil.ReadFile(src)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(path.Dir(dst), 0770); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dst, b, 0660)
+}
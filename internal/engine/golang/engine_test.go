@@ -2,9 +2,13 @@ package golang
 
 import (
 	"bytes"
+	"encoding/json"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -60,6 +64,16 @@ var fullSpec = `
                             "type": "DELETE",
                             "return_type": "return_struct_name"
                         },
+                        "method_name_6": {
+                            "type": "GET",
+                            "query_params": [
+                                {
+                                    "name": "ids",
+                                    "type": "[]uint"
+                                }
+                            ],
+                            "return_type": "[]return_struct_name"
+                        },
                         "method_name_5": {
                             "type": "PATCH",
                             "input_type": "input_struct_name",
@@ -79,6 +93,15 @@ var fullSpec = `
                         {
                             "name": "another_field_name",
                             "type": "string"
+                        },
+                        {
+                            "name": "some_list_field_name",
+                            "type": "[]float"
+                        },
+                        {
+                            "name": "signature",
+                            "type": "bytes",
+                            "optional": true
                         }
                     ]
                 },
@@ -136,7 +159,7 @@ var fullSpec = `
 `
 
 func TestGeneratedServiceCompiles(t *testing.T) {
-	pSpec, err := saasy_testing.CreateJSONSpecFile(fullSpec, ".", "spec.json")
+	pSpec, err := saasy_testing.CreateJSONSpecFile(fullSpec, ".", "spec*.json")
 	require.NoError(t, err)
 
 	pOutdir, err := saasy_testing.CreateOutdir()
@@ -145,15 +168,425 @@ func TestGeneratedServiceCompiles(t *testing.T) {
 	defer os.Remove(pSpec)
 	defer os.RemoveAll(pOutdir)
 
-	err = GenerateSourcesFromJSONSpec(pSpec, pOutdir)
+	err = GenerateSourcesFromSpec(pSpec, pOutdir)
+	require.NoError(t, err)
+
+	runGo(t, path.Join(pOutdir, "services", "foo-service"), "build", "./cmd/main.go")
+}
+
+// clientQueryTest checks that the generated client escapes the values of the query params.
+const clientQueryTest = `package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestQueryEscaping(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Write([]byte("{\"status\": 1}"))
+	}))
+	defer server.Close()
+
+	value := "a&b=c #d?e+f"
+	c := NewFooServiceClient(strings.TrimPrefix(server.URL, "http://"))
+	if _, err := c.MethodName0(context.Background(), value, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(query) != 1 || query.Get("query_param_name") != value {
+		t.Fatalf("unexpected query %v", query)
+	}
+}
+`
+
+func TestGeneratedClientEscapesQuery(t *testing.T) {
+	testGeneratedPackage(t, fullSpec, "foo-service", "pkg/client", clientQueryTest)
+}
+
+// wrapperInputTest checks that the generated HTTP wrapper reports the params and the bodies
+// it cannot parse.
+const wrapperInputTest = `package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func checkValidationError(t *testing.T, w *httptest.ResponseRecorder, field string) {
+	var e struct {
+		Kind    string ` + "`json:\"error\"`" + `
+		Payload struct {
+			Field string ` + "`json:\"field\"`" + `
+		} ` + "`json:\"payload\"`" + `
+	}
+	if err := json.NewDecoder(w.Body).Decode(&e); err != nil {
+		t.Fatal(err)
+	}
+	if w.Code != http.StatusBadRequest || e.Kind != "validation" || e.Payload.Field != field {
+		t.Fatalf("unexpected response %d %+v", w.Code, e)
+	}
+}
+
+func TestParamParseFailure(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	r.Header.Set("header_param_name", "one")
+	w := httptest.NewRecorder()
+	NewHTTPWrapper(nil).MethodName0(w, r)
+	checkValidationError(t, w, "header_param_name")
+}
+
+func TestBodyDecodingFailure(t *testing.T) {
+	for _, body := range []string{"{", ` + "`" + `{"a_field_name": "one"}` + "`" + `, ` + "`" + `{"signature": "!"}` + "`" + `} {
+		r := httptest.NewRequest(http.MethodPost, "/foo", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		NewHTTPWrapper(nil).MethodName1(w, r)
+		checkValidationError(t, w, "")
+	}
+}
+`
+
+func TestGeneratedWrapperReportsInput(t *testing.T) {
+	testGeneratedPackage(t, fullSpec, "foo-service", "internal/service", wrapperInputTest)
+}
+
+// enumSpec has a struct with an enum field which is neither required nor optional.
+var enumSpec = `
+{
+    "repository_url": "example.com/example",
+    "services": [
+        {
+            "name": "foo-service",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/users",
+                    "methods": {
+                        "create_user": { "type": "POST", "input_type": "user", "return_type": "user" }
+                    }
+                }
+            ],
+            "structs": [
+                {
+                    "name": "user",
+                    "fields": [
+                        { "name": "name", "type": "string" },
+                        { "name": "role", "type": "role" },
+                        { "name": "status", "type": "role", "required": true }
+                    ]
+                }
+            ],
+            "enums": [ { "name": "role", "values": [ "admin", "member" ] } ]
+        }
+    ]
+}
+`
+
+// enumRoundTripTest checks that unset enum fields are (un)marshalled
+// as empty values, left to Validate if required.
+const enumRoundTripTest = `package exports
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnumRoundTrip(t *testing.T) {
+	b, err := json.Marshal(User{Name: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var u User
+	if err := json.Unmarshal(b, &u); err != nil || u != (User{Name: "bob"}) {
+		t.Fatalf("unexpected %v, %v", u, err)
+	}
+	if err := json.Unmarshal([]byte("{\"role\": \"\"}"), &u); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.Validate(); err == nil {
+		t.Fatal("missing required status accepted")
+	}
+
+	if err := json.Unmarshal([]byte("{\"role\": \"owner\"}"), &u); err == nil {
+		t.Fatal("unknown role unmarshalled")
+	}
+	if _, err := json.Marshal(User{Role: "owner"}); err == nil {
+		t.Fatal("unknown role marshalled")
+	}
+}
+`
+
+func TestGeneratedEnumRoundTrip(t *testing.T) {
+	testGeneratedPackage(t, enumSpec, "foo-service", "pkg/exports", enumRoundTripTest)
+}
+
+// testGeneratedPackage generates the spec and runs the given test
+// in a package of the generated service, against this saas-y.
+func testGeneratedPackage(t *testing.T, spec, service, pkg, test string) {
+	pSpec, err := saasy_testing.CreateJSONSpecFile(spec, ".", "spec*.json")
+	require.NoError(t, err)
+
+	pOutdir, err := saasy_testing.CreateOutdir()
 	require.NoError(t, err)
 
-	// compile
+	defer os.Remove(pSpec)
+	defer os.RemoveAll(pOutdir)
+
+	require.NoError(t, GenerateSourcesFromSpec(pSpec, pOutdir))
+
+	svcDir := path.Join(pOutdir, "services", service)
+	require.NoError(t, ioutil.WriteFile(path.Join(svcDir, pkg, "saasy_test.go"), []byte(test), 0660))
+	runGo(t, svcDir, "test", "./"+pkg)
+}
+
+// TestMain has the generated modules require this saas-y, for the generated code
+// to be compiled against it.
+func TestMain(m *testing.M) {
+	os.Setenv("SAASY_SOURCE", path.Join(saasy_testing.GetTestingCommonDirectory(), "..", ".."))
+	os.Exit(m.Run())
+}
+
+// runGo runs the go command with the given args in a directory, failing the test on error.
+// The go command neither updates the go.mod and go.sum files nor downloads modules,
+// as the generated ones are complete.
+func runGo(t *testing.T, dir string, args ...string) {
 	var errout bytes.Buffer
-	cmd := exec.Command("go", "build", "./cmd/main.go")
+	cmd := exec.Command("go", args...)
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=readonly", "GOPROXY=off")
 	cmd.Stderr = &errout
-	cmd.Dir = path.Join(pOutdir, "services", "foo-service")
+	cmd.Stdout = &errout
+	cmd.Dir = dir
+	require.NoError(t, cmd.Run(), errout.String())
+}
+
+func TestGeneratedSingleModuleCompiles(t *testing.T) {
+	spec := strings.Replace(fullSpec, `"repository_url": "example.com/example",`, `"repository_url": "example.com/example", "single_module": true,`, 1)
+	pSpec, err := saasy_testing.CreateJSONSpecFile(spec, ".", "spec*.json")
+	require.NoError(t, err)
 
-	err = cmd.Run()
-	require.NoError(t, err, errout.String())
+	pOutdir, err := saasy_testing.CreateOutdir()
+	require.NoError(t, err)
+
+	defer os.Remove(pSpec)
+	defer os.RemoveAll(pOutdir)
+
+	err = GenerateSourcesFromSpec(pSpec, pOutdir)
+	require.NoError(t, err)
+
+	// the services are packages of the module of the repository
+	for _, svc := range []string{"foo-service", "bar-service"} {
+		for _, f := range []string{"go.mod", "go.sum"} {
+			_, err = os.Stat(path.Join(pOutdir, "services", svc, f))
+			require.True(t, os.IsNotExist(err), svc+"/"+f)
+		}
+	}
+
+	// compile everything at once, with the go.mod and go.sum at the root
+	runGo(t, pOutdir, "build", "./...")
+}
+
+// clashSpec has params named like the identifiers the generated methods use themselves.
+var clashSpec = `
+{
+    "repository_url": "example.com/example",
+    "services": [
+        {
+            "name": "foo-service",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/foo/{body:string}/{type:int}",
+                    "methods": {
+                        "get_foo": {
+                            "type": "GET",
+                            "query_params": [
+                                { "name": "query", "type": "string" },
+                                { "name": "url", "type": "[]int" },
+                                { "name": "request_url", "type": "int" },
+                                { "name": "ctx", "type": "bool" }
+                            ],
+                            "header_params": [ { "name": "request", "type": "int" } ]
+                        }
+                    }
+                }
+            ]
+        }
+    ]
+}
+`
+
+func TestGeneratedParamNamesCompile(t *testing.T) {
+	pSpec, err := saasy_testing.CreateJSONSpecFile(clashSpec, ".", "spec*.json")
+	require.NoError(t, err)
+
+	pOutdir, err := saasy_testing.CreateOutdir()
+	require.NoError(t, err)
+
+	defer os.Remove(pSpec)
+	defer os.RemoveAll(pOutdir)
+
+	err = GenerateSourcesFromSpec(pSpec, pOutdir)
+	require.NoError(t, err)
+
+	runGo(t, path.Join(pOutdir, "services", "foo-service"), "build", "./...")
+}
+
+// chainSpec has services depending on each other in a chain, a-service on b-service on c-service.
+var chainSpec = `
+{
+    "repository_url": "example.com/example",
+    "services": [
+        {
+            "name": "a-service",
+            "port": "80",
+            "api": [ { "path": "/a", "methods": { "get_a": { "type": "GET" } } } ],
+            "dependencies": [ "b-service" ]
+        },
+        {
+            "name": "b-service",
+            "port": "80",
+            "api": [ { "path": "/b", "methods": { "get_b": { "type": "GET" } } } ],
+            "dependencies": [ "c-service" ]
+        },
+        {
+            "name": "c-service",
+            "port": "80",
+            "api": [ { "path": "/c", "methods": { "get_c": { "type": "GET" } } } ]
+        }
+    ]
+}
+`
+
+func TestGeneratedDependencyChainCompiles(t *testing.T) {
+	pSpec, err := saasy_testing.CreateJSONSpecFile(chainSpec, ".", "spec*.json")
+	require.NoError(t, err)
+
+	pOutdir, err := saasy_testing.CreateOutdir()
+	require.NoError(t, err)
+
+	defer os.Remove(pSpec)
+	defer os.RemoveAll(pOutdir)
+
+	err = GenerateSourcesFromSpec(pSpec, pOutdir)
+	require.NoError(t, err)
+
+	// the go.mod of a-service finds c-service, required by b-service, and this saas-y on its own
+	runGo(t, path.Join(pOutdir, "services", "a-service"), "build", "./...")
+}
+
+// orderSpec has an enum and a struct of the same name in different services,
+// which must not be mistaken for one another whatever the order of the services.
+var orderSpec = `
+{
+    "repository_url": "example.com/example",
+    "structs": [
+        { "name": "shared_b", "fields": [ { "name": "id", "type": "uuid" } ] },
+        { "name": "shared_a", "fields": [ { "name": "b", "type": "shared_b" } ] }
+    ],
+    "services": [
+        {
+            "name": "foo-service",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/foo/{level:level}",
+                    "methods": {
+                        "set_level": { "type": "POST", "input_type": "shared_a" },
+                        "get_level": { "type": "GET", "return_type": "shared_a" },
+                        "watch_level": { "type": "WS" }
+                    }
+                }
+            ],
+            "enums": [ { "name": "level", "values": [ "low", "high" ] } ]
+        },
+        {
+            "name": "bar-service",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/bar",
+                    "methods": {
+                        "get_level": { "type": "GET", "return_type": "level" }
+                    }
+                }
+            ],
+            "structs": [
+                { "name": "level", "fields": [ { "name": "value", "type": "int", "min": 0 } ] },
+                { "name": "detail", "fields": [ { "name": "level", "type": "level" } ] }
+            ],
+            "dependencies": [ "foo-service" ]
+        }
+    ]
+}
+`
+
+func TestGenerationIsDeterministic(t *testing.T) {
+	// the same spec, with the services and the structs in reverse order
+	var reversed map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(orderSpec), &reversed))
+	reverse(reversed["structs"].([]interface{}))
+	reverse(reversed["services"].([]interface{}))
+	for _, svc := range reversed["services"].([]interface{}) {
+		if structs, ok := svc.(map[string]interface{})["structs"]; ok {
+			reverse(structs.([]interface{}))
+		}
+	}
+	b, err := json.Marshal(reversed)
+	require.NoError(t, err)
+
+	var outdirs []string
+	for _, spec := range []string{orderSpec, orderSpec, string(b)} {
+		pSpec, err := saasy_testing.CreateJSONSpecFile(spec, ".", "spec*.json")
+		require.NoError(t, err)
+		defer os.Remove(pSpec)
+
+		pOutdir, err := saasy_testing.CreateOutdir()
+		require.NoError(t, err)
+		defer os.RemoveAll(pOutdir)
+
+		require.NoError(t, GenerateSourcesFromSpec(pSpec, pOutdir))
+		outdirs = append(outdirs, pOutdir)
+	}
+
+	expected := readTree(t, outdirs[0])
+	require.NotEmpty(t, expected)
+	for _, outdir := range outdirs[1:] {
+		require.Equal(t, expected, readTree(t, outdir))
+	}
+}
+
+func reverse(list []interface{}) {
+	for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+		list[i], list[j] = list[j], list[i]
+	}
+}
+
+// readTree returns the contents of the files under the given directory, by relative path.
+func readTree(t *testing.T, dir string) map[string]string {
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		b, err := ioutil.ReadFile(p)
+		files[rel] = string(b)
+		return err
+	})
+	require.NoError(t, err)
+	return files
 }
@@ -0,0 +1,177 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/popescu-af/saas-y/internal/model"
+	"github.com/popescu-af/saas-y/internal/parser"
+)
+
+// ParseSpec reads the spec from an input file with the given parser and validates it.
+func ParseSpec(p parser.Abstract, inputFilePath string) (spec *model.Spec, err error) {
+	spec, err = p.Parse(inputFilePath)
+	if err != nil {
+		return
+	}
+
+	err = spec.Validate()
+	return
+}
+
+// StarterSpec returns a JSON spec to start from, the one of the tutorial,
+// with its services generated in the repository at the given URL.
+func StarterSpec(repositoryURL string) ([]byte, error) {
+	url, err := json.Marshal(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf(starterSpec, url)), nil
+}
+
+const starterSpec = `{
+    "repository_url": %s,
+    "domain": "example.com",
+    "subdomains": [
+        {
+            "name": "tutorial",
+            "paths": [
+                {
+                    "value": "/api/1.0.0",
+                    "endpoint": "tutorial-svc"
+                }
+            ]
+        }
+    ],
+    "services": [
+        {
+            "name": "tutorial-svc",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/api/1.0.0/{name:string}",
+                    "methods": {
+                        "greet": {
+                            "type": "GET",
+                            "return_type": "greeting"
+                        }
+                    }
+                }
+            ],
+            "structs": [
+                {
+                    "name": "input",
+                    "fields": [
+                        {
+                            "name": "name",
+                            "type": "string"
+                        }
+                    ]
+                },
+                {
+                    "name": "greeting",
+                    "fields": [
+                        {
+                            "name": "message",
+                            "type": "string"
+                        }
+                    ]
+                }
+            ],
+            "dependencies": ["time-svc"]
+        },
+        {
+            "name": "time-svc",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/api/1.0.0",
+                    "methods": {
+                        "get_time": {
+                            "type": "GET",
+                            "return_type": "time"
+                        }
+                    }
+                }
+            ],
+            "structs": [
+                {
+                    "name": "time",
+                    "fields": [
+                        {
+                            "name": "value",
+                            "type": "string"
+                        }
+                    ]
+                }
+            ]
+        }
+    ]
+}
+`
+
+// List prints an overview of the spec: the services with their endpoints,
+// the external services, the routes of the subdomains and the dependency graph.
+func List(spec *model.Spec, w io.Writer) {
+	fmt.Fprintln(w, "services:")
+	for _, svc := range spec.Services {
+		fmt.Fprintf(w, "  %s (port %s)\n", svc.Name, svc.Port)
+		for _, a := range svc.API {
+			names := make([]string, 0, len(a.Methods))
+			for name := range a.Methods {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				m := a.Methods[name]
+				fmt.Fprintf(w, "    %-6s %s %s%s\n", m.Type, a.Path, name, signature(m))
+			}
+		}
+	}
+
+	if len(spec.ExternalServices) > 0 {
+		fmt.Fprintln(w, "external services:")
+		for _, esvc := range spec.ExternalServices {
+			fmt.Fprintf(w, "  %s (port %s, image %s)\n", esvc.Name, esvc.Port, esvc.ImageURL)
+		}
+	}
+
+	if len(spec.Subdomains) > 0 {
+		fmt.Fprintln(w, "routes:")
+		for _, subd := range spec.Subdomains {
+			for _, p := range subd.Paths {
+				fmt.Fprintf(w, "  %s.%s%s -> %s\n", subd.Name, spec.Domain, p.Value, p.Endpoint)
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "dependencies:")
+	dependencies := func(name string, deps []string) {
+		if len(deps) == 0 {
+			fmt.Fprintf(w, "  %s\n", name)
+			return
+		}
+		fmt.Fprintf(w, "  %s -> %s\n", name, strings.Join(deps, ", "))
+	}
+	for _, svc := range spec.Services {
+		dependencies(svc.Name, svc.Dependencies)
+	}
+	for _, esvc := range spec.ExternalServices {
+		dependencies(esvc.Name, esvc.Dependencies)
+	}
+}
+
+// signature describes the input and return types of a method, e.g. (user) -> user_id.
+func signature(m model.Method) (s string) {
+	if m.InputType != "" {
+		s += " (" + m.InputType + ")"
+	}
+	if m.ReturnType != "" {
+		s += " -> " + m.ReturnType
+	}
+	return
+}
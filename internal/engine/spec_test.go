@@ -0,0 +1,55 @@
+package engine_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/internal/engine"
+	"github.com/popescu-af/saas-y/internal/parser"
+	saasytesting "github.com/popescu-af/saas-y/internal/testing"
+)
+
+func TestStarterSpec(t *testing.T) {
+	b, err := engine.StarterSpec("github.com/user/repository")
+	require.NoError(t, err)
+
+	// the starter spec is the one of the tutorial
+	tutorial, err := ioutil.ReadFile(path.Join(saasytesting.GetTestingCommonDirectory(), "..", "..", "example", "tutorial.json"))
+	require.NoError(t, err)
+	require.Equal(t, strings.Replace(string(tutorial), "github.com/user-account/destination-repository", "github.com/user/repository", 1), string(b))
+}
+
+func TestList(t *testing.T) {
+	b, err := engine.StarterSpec("github.com/user/repository")
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "saas-y-list")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	specPath := path.Join(dir, "spec.json")
+	require.NoError(t, ioutil.WriteFile(specPath, b, 0660))
+
+	spec, err := engine.ParseSpec(&parser.JSON{}, specPath)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	engine.List(spec, &out)
+	require.Equal(t, `services:
+  tutorial-svc (port 80)
+    GET    /api/1.0.0/{name:string} greet -> greeting
+  time-svc (port 80)
+    GET    /api/1.0.0 get_time -> time
+routes:
+  tutorial.example.com/api/1.0.0 -> tutorial-svc
+dependencies:
+  tutorial-svc -> time-svc
+  time-svc
+`, out.String())
+}
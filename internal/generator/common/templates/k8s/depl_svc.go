@@ -36,6 +36,19 @@ spec:
             - name: APP_{{$d | replaceHyphens | toUpper}}_ADDR
               value: "{{$d}}:8000"
             {{- end}}
+            {{- if eq .Auth.Type "api_key"}}
+            - name: APP_AUTH_API_KEYS
+              valueFrom:
+                secretKeyRef:
+                  name: auth
+                  key: api-keys
+            {{- else if eq .Auth.Type "jwt"}}
+            - name: APP_AUTH_JWT_KEY
+              valueFrom:
+                secretKeyRef:
+                  name: auth
+                  key: jwt-key
+            {{- end}}
 ---
 apiVersion: v1
 kind: Service
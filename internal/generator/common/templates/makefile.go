@@ -17,7 +17,7 @@ endif
 build:
 	docker buildx build --network host --platform ${PLATFORM} \
 		--build-arg GITHUB_URL="${GITHUB_URL}" \
-		-t {{.Name}}:${BUILD_TAG} .
+		-t {{.Name}}:${BUILD_TAG} {{if .SingleModule}}-f Dockerfile ../..{{else}}.{{end}}
 
 .PHONY: tag
 tag: build
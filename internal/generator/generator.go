@@ -2,12 +2,15 @@ package generator
 
 import (
 	"fmt"
-	"html/template"
+	"io/ioutil"
 	"log"
 	"os"
 	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"text/template"
 
 	common_templ "github.com/popescu-af/saas-y/internal/generator/common/templates"
 	"github.com/popescu-af/saas-y/internal/generator/common/templates/k8s"
@@ -21,13 +24,22 @@ type Abstract interface {
 	InternalPath() string
 	PackagePath() string
 	GetTemplate(name string) string
-	CodeFormatter(path string) (st SymbolTable, err error)
-	GenerateProject(name, path string) (err error)
+	CodeFormatter(path string) error
+	MergeCode(path string, generated []byte) (report []string, err error)
+	GenerateProject(name, path string, dependencies []LocalDependency) (err error)
+	ProjectFiles() []string
+}
+
+// LocalDependency is a project generated along with the projects depending on it.
+type LocalDependency struct {
+	Name string // name of the project, i.e. its repository URL
+	Path string // directory of the project, relative to that of the project depending on it
 }
 
 // Init initializes the generator.
 func Init() {
-	st = make(SymbolTable)
+	sharedTypes = make(map[string]bool)
+	enumTypes = make(map[string]bool)
 }
 
 // Do generates code and infrastructure declaration for the given Spec
@@ -57,6 +69,27 @@ func Do(g Abstract, spec *model.Spec, outdir string) (err error) {
 		}
 	}
 
+	// The shared package and the services are either projects of their
+	// own or packages of a single project holding the whole repository.
+	if spec.SingleModule {
+		if err = g.GenerateProject(spec.RepositoryURL, outdir, nil); err != nil {
+			return
+		}
+	}
+
+	if len(spec.Structs) > 0 || len(spec.Enums) > 0 {
+		err = Shared(g, spec.SharedRepositoryURL(), spec.Structs, spec.Enums, outdir)
+		if err != nil {
+			return
+		}
+
+		if !spec.SingleModule {
+			if err = g.GenerateProject(spec.SharedRepositoryURL(), path.Join(outdir, sharedPackage), nil); err != nil {
+				return
+			}
+		}
+	}
+
 	for _, svc := range spec.Services {
 		err = Service(g, svc, outdir)
 		if err != nil {
@@ -64,7 +97,36 @@ func Do(g Abstract, spec *model.Spec, outdir string) (err error) {
 		}
 	}
 
-	fmt.Println("Done.")
+	return
+}
+
+// Shared generates the package holding the structs and enums shared by all services.
+func Shared(g Abstract, repositoryURL string, sharedStructs []model.Struct, sharedEnums []model.Enum, outdir string) (err error) {
+	basePath := path.Join(outdir, sharedPackage)
+	if err = os.MkdirAll(basePath, 0770); err != nil {
+		return
+	}
+
+	registerEnums(sharedEnums)
+
+	// The shared types are registered only after generating their own package,
+	// where they must not be qualified with the package name.
+	err = enums(g, sharedEnums, sharedPackage, basePath)
+	if err != nil {
+		return
+	}
+
+	err = structs(g, sharedStructs, sharedPackage, "", basePath)
+	if err != nil {
+		return
+	}
+
+	for _, s := range sharedStructs {
+		sharedTypes[s.Name] = true
+	}
+	for _, e := range sharedEnums {
+		sharedTypes[e.Name] = true
+	}
 	return
 }
 
@@ -77,8 +139,10 @@ func Service(g Abstract, svc model.Service, outdir string) (err error) {
 		return
 	}
 
-	if err = g.GenerateProject(svc.RepositoryURL, basePath); err != nil {
-		return
+	if !svc.SingleModule {
+		if err = g.GenerateProject(svc.RepositoryURL, basePath, localDependencies(svc)); err != nil {
+			return
+		}
 	}
 
 	entities := []struct {
@@ -98,7 +162,23 @@ func Service(g Abstract, svc model.Service, outdir string) (err error) {
 		}
 	}
 
-	err = structs(g, svc.Structs, dirs[6])
+	// Only the enums of the service are known while generating it, not
+	// those of the services generated before, keeping the output independent
+	// of the order of the services.
+	enumTypes = make(map[string]bool)
+	registerEnums(svc.SharedEnums)
+	registerEnums(svc.Enums)
+
+	if err = OpenAPI(svc, path.Join(dirs[8], "openapi.json")); err != nil {
+		return
+	}
+
+	err = enums(g, svc.Enums, "exports", dirs[6])
+	if err != nil {
+		return
+	}
+
+	err = structs(g, svc.Structs, "exports", svc.SharedRepositoryURL, dirs[6])
 	if err != nil {
 		return
 	}
@@ -107,16 +187,17 @@ func Service(g Abstract, svc model.Service, outdir string) (err error) {
 		template string
 		outdir   string
 	}{
-		// KEEP THIS ORDER !!!
 		{"impl", dirs[4]},
 		{"client", dirs[7]},
 		{"api", dirs[6]},
+		{"api_errors", dirs[6]},
 		{"errors", dirs[4]},
 		{"main", dirs[1]},
 		{"env", dirs[3]},
 		{"http_router", dirs[5]},
 		{"http_wrapper", dirs[5]},
 		{"http_error_handler", dirs[5]},
+		{"middleware", dirs[5]},
 	}
 
 	for _, c := range components {
@@ -127,7 +208,6 @@ func Service(g Abstract, svc model.Service, outdir string) (err error) {
 	}
 
 	// TODO:
-	// - add support for byte data in structs and for arrays
 	// - proper error when some field is missing (e.g. return_type)
 	// - README.md on how to use saas-y
 	//   - test the usage of readme
@@ -136,15 +216,12 @@ func Service(g Abstract, svc model.Service, outdir string) (err error) {
 	//
 	// New features:
 	// - move code to internal, put client code into pkg
-	// - support [] of data (POD or struct) as structure member attribute
 	// - support null return from API
 	// - generate client code snippets
 	//   - add env variable for connectivity to the dependencies
 	//   - generate wrapper over HTTP client code to be easily accessible by logic package
 	// - linkage between saas-y generated services
 	// - code/example for talking to well-known services/tools (redis, etc.)
-	// - CORS
-	// - authentication
 	// - unit tests for the generated service (everything excluding the pure logic)
 	//
 	// Ideas:
@@ -153,6 +230,23 @@ func Service(g Abstract, svc model.Service, outdir string) (err error) {
 	return
 }
 
+// localDependencies returns the projects generated along with the service which it depends
+// on, i.e. the package of the shared structs and enums and the services it depends on,
+// directly or through other services.
+func localDependencies(svc model.Service) (dependencies []LocalDependency) {
+	if svc.SharedRepositoryURL != "" {
+		dependencies = append(dependencies, LocalDependency{Name: svc.SharedRepositoryURL, Path: path.Join("..", "..", sharedPackage)})
+	}
+	for _, infos := range [][]model.DependencyInfo{svc.DependencyInfos, svc.IndirectDependencyInfos} {
+		for _, d := range infos {
+			if !d.External {
+				dependencies = append(dependencies, LocalDependency{Name: d.RepositoryURL, Path: path.Join("..", d.Name)})
+			}
+		}
+	}
+	return
+}
+
 func serviceDirs(g Abstract, basePath string) (dirs []string, err error) {
 	dirs = []string{
 		basePath,
@@ -163,6 +257,7 @@ func serviceDirs(g Abstract, basePath string) (dirs []string, err error) {
 		path.Join(basePath, g.InternalPath(), "service"),
 		path.Join(basePath, g.PackagePath(), "exports"),
 		path.Join(basePath, g.PackagePath(), "client"),
+		path.Join(basePath, "api"),
 	}
 
 	for _, dir := range dirs {
@@ -173,26 +268,130 @@ func serviceDirs(g Abstract, basePath string) (dirs []string, err error) {
 	return
 }
 
+// handWrittenComponents returns the components meant to be edited, which are generated only once,
+// with their directories relative to that of the service. Regenerating them merges the newly
+// generated code into the existing files, see MergeCode.
+func handWrittenComponents(g Abstract) map[string]string {
+	return map[string]string{
+		"impl":               path.Join(g.InternalPath(), "logic"),
+		"errors":             path.Join(g.InternalPath(), "logic"),
+		"http_error_handler": path.Join(g.InternalPath(), "service"),
+		"middleware":         path.Join(g.InternalPath(), "service"),
+	}
+}
+
+// PreservedFiles returns the paths, relative to the output directory, of the files which
+// are generated only once and then kept or merged into when regenerating the spec,
+// i.e. the project files of the packages and the hand-written service components.
+func PreservedFiles(g Abstract, spec *model.Spec) (files []string) {
+	if spec.SingleModule {
+		files = append(files, g.ProjectFiles()...)
+	} else if len(spec.Structs) > 0 || len(spec.Enums) > 0 {
+		for _, f := range g.ProjectFiles() {
+			files = append(files, path.Join(sharedPackage, f))
+		}
+	}
+
+	for _, svc := range spec.Services {
+		basePath := path.Join("services", svc.Name)
+		if !spec.SingleModule {
+			for _, f := range g.ProjectFiles() {
+				files = append(files, path.Join(basePath, f))
+			}
+		}
+		components := handWrittenComponents(g)
+		names := make([]string, 0, len(components))
+		for name := range components {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			files = append(files, path.Join(basePath, components[name], name+g.FileExtension()))
+		}
+	}
+	return
+}
+
 func serviceComponent(g Abstract, svc model.Service, componentName, outdir string) (err error) {
 	filler := templateFiller(g.GetTemplate(componentName), g.CodeFormatter)
 	fPath := path.Join(outdir, componentName+g.FileExtension())
-	err = filler(svc, fPath)
+
+	if _, statErr := os.Stat(fPath); statErr != nil || handWrittenComponents(g)[componentName] == "" {
+		err = filler(svc, fPath)
+		return
+	}
+
+	// The component is generated aside and merged into the existing file.
+	f, err := ioutil.TempFile("", "saas-y-*"+g.FileExtension())
+	if err != nil {
+		return
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	if err = filler(svc, f.Name()); err != nil {
+		return
+	}
+
+	generated, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return
+	}
+
+	report, err := g.MergeCode(fPath, generated)
+	if err != nil {
+		return
+	}
+	for _, r := range report {
+		fmt.Fprintf(os.Stderr, "Warning: %s: %s\n", fPath, r)
+	}
 	return
 }
 
-func structs(g Abstract, structs []model.Struct, outdir string) (err error) {
+func structs(g Abstract, structs []model.Struct, pkg, sharedRepositoryURL, outdir string) (err error) {
 	filler := templateFiller(g.GetTemplate("struct"), g.CodeFormatter)
+
 	for _, s := range structs {
 		fPath := path.Join(outdir, s.Name+g.FileExtension())
-		err = filler(s, fPath)
+		err = filler(structData{Struct: s, Package: pkg, SharedRepositoryURL: sharedRepositoryURL}, fPath)
 		if err != nil {
 			return
 		}
 	}
+	return
+}
 
+func enums(g Abstract, enums []model.Enum, pkg, outdir string) (err error) {
+	filler := templateFiller(g.GetTemplate("enum"), g.CodeFormatter)
+	for _, e := range enums {
+		fPath := path.Join(outdir, e.Name+g.FileExtension())
+		err = filler(enumData{Enum: e, Package: pkg}, fPath)
+		if err != nil {
+			return
+		}
+	}
 	return
 }
 
+func registerEnums(enums []model.Enum) {
+	for _, e := range enums {
+		enumTypes[e.Name] = true
+	}
+}
+
+// enumData is what the enum template is filled with.
+type enumData struct {
+	model.Enum
+	Package string
+}
+
+// structData is what the struct template is filled with.
+type structData struct {
+	model.Struct
+	Package             string
+	SharedRepositoryURL string
+}
+
 // CommonEntity generates an entity that is common to all languages.
 func CommonEntity(obj interface{}, templ string, resultPath string) (err error) {
 	loadedTempl := template.Must(template.New("templ").
@@ -222,7 +421,7 @@ func CommonEntity(obj interface{}, templ string, resultPath string) (err error)
 
 type templateFillerFunction func(interface{}, string) error
 
-func templateFiller(templ string, codeFormatter func(string) (SymbolTable, error)) templateFillerFunction {
+func templateFiller(templ string, codeFormatter func(string) error) templateFillerFunction {
 	paramStack := ""
 	foundWebSocket := false
 
@@ -244,13 +443,44 @@ func templateFiller(templ string, codeFormatter func(string) (SymbolTable, error
 				foundWebSocket = false
 				return ""
 			},
-			"decapitalize":    func(s string) string { return strings.ToLower(s[:1]) + s[1:] },
-			"capitalize":      func(s string) string { return strings.ToUpper(s[:1]) + s[1:] },
-			"toLower":         strings.ToLower,
-			"toUpper":         strings.ToUpper,
-			"symbolize":       symbolize,
-			"typeName":        typeName,
-			"typePlaceholder": typePlaceholder,
+			"capitalize":        func(s string) string { return strings.ToUpper(s[:1]) + s[1:] },
+			"exported":          exportedName,
+			"unexported":        unexportedName,
+			"paramName":         paramName,
+			"toLower":           strings.ToLower,
+			"toUpper":           strings.ToUpper,
+			"typeName":          typeName,
+			"qualifiedTypeName": qualifiedTypeName,
+			"fieldTypeName":     func(v model.Variable) string { return typeName(v.FieldType()) },
+			"fieldChecks":       fieldChecks,
+			"typePlaceholder":   typePlaceholder,
+			"isArrayType":       model.IsArrayType,
+			"isContainerType":   model.IsContainerType,
+			"isEnumType":        isEnumType,
+			"valueParserName":   valueParserName,
+			"paramValue":        paramValue,
+			"isPrimitiveType":   model.IsPrimitiveType,
+			"usesType": func(vars []model.Variable, types ...string) bool {
+				for _, v := range vars {
+					for _, t := range types {
+						if model.BaseType(v.Type) == t {
+							return true
+						}
+					}
+				}
+				return false
+			},
+			"hasEnumType": func(vars []model.Variable) bool {
+				for _, v := range vars {
+					if isEnumType(v.Type) {
+						return true
+					}
+				}
+				return false
+			},
+			"elementType":     model.ElementType,
+			"join":            strings.Join,
+			"middlewareNames": middlewareNames,
 			"pathParameters":  pathParameters,
 			"pathHasParameters": func(s string) string {
 				ss := strings.Split(s, "/")
@@ -283,7 +513,7 @@ func templateFiller(templ string, codeFormatter func(string) (SymbolTable, error
 					}
 
 					fmtString += typePlaceholder(params[pIdx+1])
-					argString += ", " + params[pIdx]
+					argString += ", " + paramValue(params[pIdx+1], paramName(params[pIdx]))
 					pIdx += 2
 				}
 
@@ -306,8 +536,8 @@ func templateFiller(templ string, codeFormatter func(string) (SymbolTable, error
 				return ""
 			},
 			"cleanPath": func(p string) string {
-				re := regexp.MustCompile(`:(int|uint|float|string)`)
-				return re.ReplaceAllString(p, "")
+				re := regexp.MustCompile(`\{([A-Za-z0-9_]+):[A-Za-z0-9_]+\}`)
+				return re.ReplaceAllString(p, "{$1}")
 			},
 			"cleanName": func(n string) string {
 				result := n[:1]
@@ -338,13 +568,7 @@ func templateFiller(templ string, codeFormatter func(string) (SymbolTable, error
 			return
 		}
 
-		newSymTable, err := codeFormatter(resultPath)
-		if err == nil {
-			for k, v := range newSymTable {
-				st[k] = v
-			}
-		}
-		return
+		return codeFormatter(resultPath)
 	}
 }
 
@@ -359,20 +583,192 @@ func applyObjectToTemplateAndSaveToFile(obj interface{}, loadedTempl *template.T
 	return
 }
 
-// SymbolTable is a map translating original symbol names to symbol names
-// conforming to the naming style of the generated language.
-type SymbolTable map[string]string
+// sharedPackage is the name of the package holding the shared structs.
+const sharedPackage = "shared"
+
+// sharedTypes is the set of structs and enums that are shared by all services.
+var sharedTypes map[string]bool
+
+// enumTypes is the set of known enums, both shared and service-specific.
+var enumTypes map[string]bool
+
+func isEnumType(t string) bool {
+	return enumTypes[t]
+}
+
+// enumParserName returns the name of the function parsing
+// values of the given enum, qualified like the enum itself.
+func enumParserName(pkg, t string) string {
+	name := "Parse" + exportedName(t)
+	if sharedTypes[t] {
+		return sharedPackage + "." + name
+	}
+	if pkg != "" {
+		return pkg + "." + name
+	}
+	return name
+}
+
+// fieldChecks returns the code checking a struct field against its
+// declared presence and value constraints, as used in Validate methods.
+func fieldChecks(v model.Variable) string {
+	field := "s." + exportedName(v.Name)
+	name := strconv.Quote(v.Name)
+	t := v.FieldType()
+	code := ""
+
+	if v.Required {
+		cond := field + ` == ""`
+		switch {
+		case model.IsContainerType(t) || t == "bytes":
+			cond = "len(" + field + ") == 0"
+		case model.IsPointerType(t):
+			cond = field + " == nil"
+		case t == "time":
+			cond = field + ".IsZero()"
+		case t == "uuid":
+			cond = field + " == uuid.Nil"
+		}
+		code += checkCode(cond, "validation.Errorf("+name+`, "is required")`)
+	}
+
+	switch {
+	case model.IsContainerType(t):
+		code += lengthChecks(v, name, "len("+field+")")
+		code += containerChecks(v, t, field, name, 0)
+	case model.IsPointerType(t) && !isStructType(model.PointeeType(t)):
+		if pointeeCode := valueChecks(v, model.PointeeType(t), "*"+field, name, 0); pointeeCode != "" {
+			code += "if " + field + " != nil {\n" + pointeeCode + "}\n"
+		}
+	default:
+		code += valueChecks(v, t, field, name, 0)
+	}
+
+	return code
+}
+
+// containerChecks returns the code checking each value of an array or a map.
+// The depth keeps the loop variables of nested containers apart.
+func containerChecks(v model.Variable, t, value, name string, depth int) string {
+	key, elem, elemName := "i", "v", "validation.Index("
+	if model.IsMapType(t) {
+		key, elemName = "k", "validation.Key("
+	}
+	if depth > 0 {
+		key += strconv.Itoa(depth)
+		elem += strconv.Itoa(depth)
+	}
+
+	code := valueChecks(v, model.ContainedType(t), elem, elemName+name+", "+key+")", depth+1)
+	if code == "" {
+		return ""
+	}
+	return "for " + key + ", " + elem + " := range " + value + " {\n" + code + "}\n"
+}
+
+// valueChecks returns the code checking a single value of the given type.
+func valueChecks(v model.Variable, t, value, name string, depth int) (code string) {
+	if model.IsContainerType(t) {
+		return containerChecks(v, t, value, name, depth)
+	}
+	if model.IsPointerType(t) {
+		if isStructType(model.PointeeType(t)) {
+			code = "if " + value + " != nil {\n" + nestedCheck(value, name) + "}\n"
+		}
+		return
+	}
+
+	switch {
+	case t == "int" || t == "uint" || t == "float":
+		if v.Min != nil {
+			code += checkError("validation.Min(" + name + ", float64(" + value + "), " + formatNumber(*v.Min) + ")")
+		}
+		if v.Max != nil {
+			code += checkError("validation.Max(" + name + ", float64(" + value + "), " + formatNumber(*v.Max) + ")")
+		}
+	case t == "bytes":
+		if !model.IsContainerType(v.Type) {
+			code += lengthChecks(v, name, "len("+value+")")
+		}
+	case t == "string":
+		if !model.IsContainerType(v.Type) {
+			code += lengthChecks(v, name, "len([]rune("+value+"))")
+		}
+		if v.Pattern != "" {
+			code += checkError("validation.Pattern(" + name + ", " + value + ", " + strconv.Quote(v.Pattern) + ")")
+		}
+	case isStructType(t):
+		code += nestedCheck(value, name)
+	}
+	return
+}
+
+func lengthChecks(v model.Variable, name, length string) (code string) {
+	if v.MinLength != nil {
+		code += checkError("validation.MinLength(" + name + ", " + length + ", " + strconv.Itoa(*v.MinLength) + ")")
+	}
+	if v.MaxLength != nil {
+		code += checkError("validation.MaxLength(" + name + ", " + length + ", " + strconv.Itoa(*v.MaxLength) + ")")
+	}
+	return
+}
 
-var st SymbolTable
+func nestedCheck(value, name string) string {
+	return "if err := " + value + ".Validate(); err != nil {\nreturn validation.Nested(" + name + ", err)\n}\n"
+}
 
-func symbolize(originalName string) string {
-	if translatedName, ok := st[originalName]; ok {
-		return translatedName
+func checkError(call string) string {
+	return "if err := " + call + "; err != nil {\nreturn err\n}\n"
+}
+
+func checkCode(cond, errValue string) string {
+	return "if " + cond + " {\nreturn " + errValue + "\n}\n"
+}
+
+// middlewareNames returns the names of the middleware declared for the methods of the APIs, sorted.
+func middlewareNames(apis []model.API) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, a := range apis {
+		for _, m := range a.Methods {
+			for _, name := range m.Middleware {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
 	}
-	return originalName
+	sort.Strings(names)
+	return names
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// isStructType tells if the given type is a struct, i.e. it has a Validate method.
+func isStructType(t string) bool {
+	return t != "" && !model.IsPrimitiveType(t) && !isEnumType(t) && !model.IsContainerType(t) && !model.IsPointerType(t)
 }
 
 func typeName(t string) string {
+	return qualifiedTypeName("", t)
+}
+
+// qualifiedTypeName translates a saas-y type to a go type, prefixing
+// the names of API structures with the given package name, if any.
+func qualifiedTypeName(pkg, t string) string {
+	if model.IsArrayType(t) {
+		return "[]" + qualifiedTypeName(pkg, model.ElementType(t))
+	}
+	if model.IsMapType(t) {
+		return "map[" + qualifiedTypeName(pkg, model.MapKeyType(t)) + "]" + qualifiedTypeName(pkg, model.MapValueType(t))
+	}
+	if model.IsPointerType(t) {
+		return "*" + qualifiedTypeName(pkg, model.PointeeType(t))
+	}
+
 	switch t {
 	case "int":
 		return "int64"
@@ -382,26 +778,75 @@ func typeName(t string) string {
 		return "float64"
 	case "string":
 		return "string"
+	case "bool":
+		return "bool"
+	case "bytes":
+		return "[]byte"
+	case "time":
+		return "time.Time"
+	case "duration":
+		return "time.Duration"
+	case "uuid":
+		return "uuid.UUID"
+	case "":
+		return ""
 	}
 
-	return ""
+	name := exportedName(t)
+	if sharedTypes[t] {
+		return sharedPackage + "." + name
+	}
+	if pkg != "" {
+		return pkg + "." + name
+	}
+	return name
 }
 
 func typePlaceholder(t string) string {
-	switch t {
+	if isEnumType(model.ElementType(t)) {
+		return "%s"
+	}
+
+	switch model.ElementType(t) {
 	case "int":
 		return "%d"
 	case "uint":
 		return "%d"
 	case "float":
 		return "%f"
-	case "string":
+	case "string", "bytes", "time", "duration", "uuid":
 		return "%s"
+	case "bool":
+		return "%t"
 	}
 
 	return ""
 }
 
+// paramValue returns the expression formatting a param value for use in URLs and headers.
+// Bytes are URL-safe base64 encoded and times are formatted as RFC 3339 in UTC.
+func paramValue(t, value string) string {
+	switch model.ElementType(t) {
+	case "bytes":
+		return "base64.URLEncoding.EncodeToString(" + value + ")"
+	case "time":
+		return value + ".UTC().Format(time.RFC3339Nano)"
+	}
+	return value
+}
+
+// valueParserName returns the name of the function parsing param values of the given type,
+// for types parsed by functions from other packages, i.e. enums and uuids.
+func valueParserName(t string) string {
+	if isEnumType(t) {
+		return enumParserName("exports", t)
+	}
+	if t == "uuid" {
+		return "uuid.Parse"
+	}
+	return ""
+}
+
 func pathParameters(s string) (result []string) {
 	paramMap := make(map[string]string)
 
@@ -422,12 +867,10 @@ func pathParameters(s string) (result []string) {
 		}
 
 		pType := tokens[1][:len(tokens[1])-1]
-		for _, t := range []string{"int", "uint", "float", "string"} {
-			if t == pType {
-				result = append(result, pName)
-				result = append(result, pType)
-				paramMap[pName] = pType
-			}
+		if model.IsPrimitiveType(pType) || isEnumType(pType) {
+			result = append(result, pName)
+			result = append(result, pType)
+			paramMap[pName] = pType
 		}
 		if _, ok := paramMap[pName]; !ok {
 			log.Fatalf("invalid type for parameter '%s': '%s'", pName, pType)
@@ -1,8 +1,11 @@
 package generator_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,10 +13,17 @@ import (
 	"github.com/popescu-af/saas-y/internal/generator"
 	"github.com/popescu-af/saas-y/internal/generator/common/templates/k8s"
 	gengo "github.com/popescu-af/saas-y/internal/generator/go"
+	"github.com/popescu-af/saas-y/internal/generator/go/templates"
 	"github.com/popescu-af/saas-y/internal/model"
 	saasytesting "github.com/popescu-af/saas-y/internal/testing"
 )
 
+// TestMain has the generated modules require saas-y from a placeholder source directory.
+func TestMain(m *testing.M) {
+	os.Setenv("SAASY_SOURCE", "/path/to/saas-y")
+	os.Exit(m.Run())
+}
+
 func generateServiceFiles(svc model.Service) (pOutdir string, err error) {
 	pOutdir, err = saasytesting.CreateOutdir()
 	if err != nil {
@@ -36,6 +46,11 @@ func TestGeneratedEnv(t *testing.T) {
 					Type:  "int64",
 					Value: "42",
 				},
+				{
+					Name:  "ENV_GREETING",
+					Type:  "string",
+					Value: "Tom & Jerry <3",
+				},
 			},
 		},
 		API:     []model.API{},
@@ -218,6 +233,495 @@ func TestGeneratedStruct(t *testing.T) {
 	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"hakuna_matata.go"})
 }
 
+func TestGeneratedNestedStructs(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name: "foo-service",
+			Port: "80",
+		},
+		API: []model.API{},
+		Structs: []model.Struct{
+			{
+				Name: "order",
+				Fields: []model.Variable{
+					{Name: "customer", Type: "customer_info"},
+					{Name: "delivery_address", Type: "*address"},
+					{Name: "previous_addresses", Type: "[]address"},
+				},
+			},
+			{
+				Name: "customer_info",
+				Fields: []model.Variable{
+					{Name: "name", Type: "string"},
+					{Name: "last_order", Type: "*order"},
+				},
+			},
+			{
+				Name: "address",
+				Fields: []model.Variable{
+					{Name: "street", Type: "string"},
+				},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_structs")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"order.go", "customer_info.go"})
+}
+
+func TestGeneratedSharedStructs(t *testing.T) {
+	shared := []model.Struct{
+		{
+			Name: "user",
+			Fields: []model.Variable{
+				{Name: "name", Type: "string"},
+				{Name: "home_address", Type: "address"},
+			},
+		},
+		{
+			Name: "address",
+			Fields: []model.Variable{
+				{Name: "street", Type: "string"},
+			},
+		},
+	}
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/users",
+				Methods: map[string]model.Method{
+					"get_users":   {Type: model.GET, ReturnType: "[]user"},
+					"create_user": {Type: model.POST, InputType: "user", ReturnType: "order"},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "order",
+				Fields: []model.Variable{
+					{Name: "buyer", Type: "user"},
+				},
+			},
+		},
+		SharedRepositoryURL: "foo/shared",
+	}
+
+	generator.Init()
+
+	pOutdir, err := saasytesting.CreateOutdir()
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	err = generator.Shared(&gengo.Generator{}, svc.SharedRepositoryURL, shared, nil, pOutdir)
+	require.NoError(t, err)
+
+	err = generator.Service(&gengo.Generator{}, svc, pOutdir)
+	require.NoError(t, err)
+
+	pSvcOutdir := path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_shared")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "shared"), referenceDir, []string{"user.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pSvcOutdir, "internal", "logic"), referenceDir, []string{"impl.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pSvcOutdir, "pkg", "exports"), referenceDir, []string{"api.go", "order.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pSvcOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
+}
+
+func TestGeneratedEnums(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+			Environment: []model.Variable{
+				{Name: "DEFAULT_STATUS", Type: "order_status", Value: "pending"},
+			},
+		},
+		API: []model.API{
+			{
+				Path: "/orders/{status:order_status}",
+				Methods: map[string]model.Method{
+					"get_orders": {
+						Type:         model.GET,
+						QueryParams:  []model.Variable{{Name: "previous_statuses", Type: "[]order_status"}},
+						HeaderParams: []model.Variable{{Name: "min_status", Type: "order_status"}},
+						ReturnType:   "[]order",
+					},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "order",
+				Fields: []model.Variable{
+					{Name: "status", Type: "order_status"},
+				},
+			},
+		},
+		Enums: []model.Enum{
+			{Name: "order_status", Values: []string{"pending", "in_progress", "DONE"}},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_enums")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "config"), referenceDir, []string{"env.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_wrapper.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"api.go", "order.go", "order_status.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
+}
+
+func TestGeneratedValidation(t *testing.T) {
+	min, max := 0.0, 150.0
+	one, sixtyFour, ten := 1, 64, 10
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/users",
+				Methods: map[string]model.Method{
+					"create_user": {
+						Type:         model.POST,
+						InputType:    "user",
+						QueryParams:  []model.Variable{{Name: "dry_run", Type: "int"}, {Name: "group", Type: "string", Required: true}},
+						HeaderParams: []model.Variable{{Name: "request_id", Type: "uint", Required: true}},
+						ReturnType:   "user",
+					},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "user",
+				Fields: []model.Variable{
+					{Name: "name", Type: "string", Required: true, MinLength: &one, MaxLength: &sixtyFour, Pattern: "^[A-Za-z ]+$"},
+					{Name: "age", Type: "int", Optional: true, Min: &min, Max: &max},
+					{Name: "email", Type: "string", Optional: true, Pattern: "^[^@]+@[^@]+$"},
+					{Name: "tags", Type: "[]string", MaxLength: &ten, Pattern: "^[a-z]+$"},
+					{Name: "scores", Type: "[]float", Required: true, Min: &min},
+					{Name: "address", Type: "address", Optional: true},
+				},
+			},
+			{
+				Name: "address",
+				Fields: []model.Variable{
+					{Name: "city", Type: "string", Required: true},
+				},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_validation")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_wrapper.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"user.go", "address.go"})
+}
+
+func TestGeneratedErrors(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/users/{id:uuid}",
+				Methods: map[string]model.Method{
+					"get_user": {Type: model.GET, ReturnType: "user"},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name:   "user",
+				Fields: []model.Variable{{Name: "name", Type: "string"}},
+			},
+		},
+		Errors: []model.ErrorKind{
+			{Name: "user_not_found", Status: 404},
+			{Name: "user_blocked", Status: 403, Payload: "user"},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_errors")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"api_errors.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "logic"), referenceDir, []string{"errors.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_error_handler.go"})
+}
+
+func TestGeneratedMiddleware(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/items",
+				Methods: map[string]model.Method{
+					"list_items":  {Type: model.GET, ReturnType: "[]string"},
+					"create_item": {Type: model.POST, Middleware: []string{"auth", "rate_limit"}},
+				},
+			},
+			{
+				Path: "/items/watch",
+				Methods: map[string]model.Method{
+					"watch_items": {Type: model.WS, Middleware: []string{"auth"}},
+				},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_middleware")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_router.go", "http_wrapper.go", "middleware.go"})
+}
+
+func TestGeneratedAuth(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/items",
+				Methods: map[string]model.Method{
+					"list_items":  {Type: model.GET, ReturnType: "[]string", Auth: model.AuthOptional},
+					"create_item": {Type: model.POST, Middleware: []string{"rate_limit"}},
+				},
+			},
+			{
+				Path: "/items/watch",
+				Methods: map[string]model.Method{
+					"watch_items": {Type: model.WS},
+				},
+			},
+			{
+				Path: "/health",
+				Methods: map[string]model.Method{
+					"health": {Type: model.GET, Auth: model.AuthNone},
+				},
+			},
+		},
+		Auth: model.Auth{Type: model.AuthTypeJWT, Algorithm: "RS256"},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_auth")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_wrapper.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "config"), referenceDir, []string{"env.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "cmd"), referenceDir, []string{"main.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
+}
+
+func TestGeneratedCORS(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/items",
+				Methods: map[string]model.Method{
+					"list_items":  {Type: model.GET, ReturnType: "[]string"},
+					"create_item": {Type: model.POST, Middleware: []string{"rate_limit"}},
+				},
+			},
+			{
+				Path: "/items/watch",
+				Methods: map[string]model.Method{
+					"watch_items": {Type: model.WS},
+				},
+			},
+		},
+		CORS: &model.CORS{
+			AllowedOrigins:   []string{"https://app.example.com", "http://localhost:3000"},
+			AllowedHeaders:   []string{"Content-Type", "X-Request-Id"},
+			AllowCredentials: true,
+			MaxAge:           600,
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_cors")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_router.go", "http_wrapper.go"})
+}
+
+func TestGeneratedPrimitiveTypes(t *testing.T) {
+	ten := 10
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+			Environment: []model.Variable{
+				{Name: "VERBOSE", Type: "bool", Value: "true"},
+				{Name: "TIMEOUT", Type: "duration", Value: "1m30s"},
+				{Name: "INSTANCE_ID", Type: "uuid", Value: "123e4567-e89b-12d3-a456-426614174000"},
+			},
+		},
+		API: []model.API{
+			{
+				Path: "/events/{id:uuid}/{at:time}",
+				Methods: map[string]model.Method{
+					"get_event": {
+						Type: model.GET,
+						QueryParams: []model.Variable{
+							{Name: "verbose", Type: "bool"},
+							{Name: "window", Type: "duration"},
+							{Name: "tags", Type: "[]uuid"},
+							{Name: "cursor", Type: "bytes"},
+						},
+						HeaderParams: []model.Variable{{Name: "since", Type: "time"}},
+						ReturnType:   "event",
+					},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "event",
+				Fields: []model.Variable{
+					{Name: "id", Type: "uuid", Required: true},
+					{Name: "happened_at", Type: "time", Required: true},
+					{Name: "lasted", Type: "duration"},
+					{Name: "payload", Type: "bytes", Required: true},
+					{Name: "signature", Type: "bytes", Optional: true, MaxLength: &ten},
+					{Name: "public", Type: "bool", Optional: true},
+				},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_primitives")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "config"), referenceDir, []string{"env.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_wrapper.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"api.go", "event.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
+}
+
+func TestGeneratedMaps(t *testing.T) {
+	ten := 10
+	one := 1.0
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/resources",
+				Methods: map[string]model.Method{
+					"list_resources": {
+						Type:       model.GET,
+						ReturnType: "map<string,resource>",
+					},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "resource",
+				Fields: []model.Variable{
+					{Name: "labels", Type: "map<string,string>", MaxLength: &ten, Pattern: "^[a-z]*$"},
+					{Name: "counts", Type: "map<int, uint>", Min: &one},
+					{Name: "children", Type: "map<string,child>", Required: true},
+					{Name: "links", Type: "map<string,*child>", Optional: true},
+					{Name: "groups", Type: "map<string,[]child>"},
+				},
+			},
+			{
+				Name: "child",
+				Fields: []model.Variable{
+					{Name: "name", Type: "string", Required: true},
+				},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_maps")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "logic"), referenceDir, []string{"impl.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"api.go", "resource.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
+}
+
 func TestGeneratedWebsocketMethod(t *testing.T) {
 	qParams := []model.Variable{
 		{Name: "query_param_0", Type: "int"},
@@ -274,3 +778,222 @@ func TestGeneratedWebsocketMethod(t *testing.T) {
 	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"api.go"})
 	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
 }
+
+func TestGeneratedArrays(t *testing.T) {
+	qParams := []model.Variable{
+		{Name: "query_param_0", Type: "[]int"},
+		{Name: "query_param_1", Type: "[]string"},
+		{Name: "query_param_2", Type: "float"},
+	}
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/some_path",
+				Methods: map[string]model.Method{
+					"method_0": {Type: model.GET, QueryParams: qParams, ReturnType: "[]item"},
+					"method_1": {Type: model.GET, ReturnType: "[]string"},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "item",
+				Fields: []model.Variable{
+					{Name: "tags", Type: "[]string"},
+					{Name: "scores", Type: "[]float"},
+				},
+			},
+			{
+				Name: "catalog",
+				Fields: []model.Variable{
+					{Name: "items", Type: "[]item"},
+				},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_arrays")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "logic"), referenceDir, []string{"impl.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "internal", "service"), referenceDir, []string{"http_wrapper.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "exports"), referenceDir, []string{"api.go", "item.go", "catalog.go"})
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "pkg", "client"), referenceDir, []string{"client.go"})
+}
+
+func TestRegeneratedImpl(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/items/{id:uuid}",
+				Methods: map[string]model.Method{
+					"get_item":    {Type: model.GET, ReturnType: "item"},
+					"delete_item": {Type: model.DELETE},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name:   "item",
+				Fields: []model.Variable{{Name: "name", Type: "string"}},
+			},
+		},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pLogic := path.Join(pOutdir, "services", svc.Name, "internal", "logic")
+	pImpl := path.Join(pLogic, "impl.go")
+
+	// hand-written implementation
+	b, err := ioutil.ReadFile(pImpl)
+	require.NoError(t, err)
+	impl := strings.Replace(string(b), `return nil, errors.New("method 'get_item' not implemented")`, `return &exports.Item{Name: id.String()}, nil`, 1)
+	require.NoError(t, ioutil.WriteFile(pImpl, []byte(impl), 0660))
+
+	// the spec changes, a method being removed, another added and another one changed
+	svc.API[0].Methods = map[string]model.Method{
+		"get_item":    {Type: model.GET, ReturnType: "item"},
+		"update_item": {Type: model.PATCH, InputType: "item", ReturnType: "item"},
+	}
+	svc.API = append(svc.API, model.API{
+		Path: "/items",
+		Methods: map[string]model.Method{
+			"list_items": {Type: model.GET, QueryParams: []model.Variable{{Name: "limit", Type: "int"}}, ReturnType: "[]item"},
+		},
+	})
+
+	generator.Init()
+
+	err = generator.Service(&gengo.Generator{}, svc, pOutdir)
+	require.NoError(t, err)
+
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "regenerated_impl")
+	saasytesting.CheckFilesInDirsEqual(t, pLogic, referenceDir, []string{"impl.go"})
+
+	// merging again changes nothing, but still reports the leftovers
+	b, err = ioutil.ReadFile(pImpl)
+	require.NoError(t, err)
+
+	report, err := (&gengo.Generator{}).MergeCode(pImpl, []byte(`package logic
+
+type Implementation struct {
+}
+
+func (i *Implementation) GetItem(ctx context.Context, id uuid.UUID, verbose bool) (*exports.Item, error) {
+	return nil, nil
+}
+`))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"Implementation.GetItem has the signature (context.Context, uuid.UUID) (*exports.Item, error), expected (context.Context, uuid.UUID, bool) (*exports.Item, error)",
+		"Implementation.DeleteItem is implemented but no longer in the spec",
+		"Implementation.UpdateItem is implemented but no longer in the spec",
+		"Implementation.ListItems is implemented but no longer in the spec",
+	}, report)
+
+	merged, err := ioutil.ReadFile(pImpl)
+	require.NoError(t, err)
+	require.Equal(t, b, merged)
+}
+
+func TestGeneratedGoMod(t *testing.T) {
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "example.com/example/services/foo-service",
+			Port:          "80",
+			Dependencies:  []string{"bar-service", "redis"},
+		},
+		DependencyInfos: []model.DependencyInfo{
+			{Name: "bar-service", RepositoryURL: "example.com/example/services/bar-service"},
+			{Name: "redis", RepositoryURL: "github.com/example/redis", External: true},
+		},
+		IndirectDependencyInfos: []model.DependencyInfo{
+			{Name: "baz-service", RepositoryURL: "example.com/example/services/baz-service"},
+		},
+		SharedRepositoryURL: "example.com/example/shared",
+	}
+
+	generator.Init()
+
+	pOutdir, err := saasytesting.CreateOutdir()
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pSvcOutdir := path.Join(pOutdir, "services", svc.Name)
+	generate := func() (goMod, goSum string) {
+		err := generator.Service(&gengo.Generator{}, svc, pOutdir)
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadFile(path.Join(pSvcOutdir, "go.mod"))
+		require.NoError(t, err)
+		sum, err := ioutil.ReadFile(path.Join(pSvcOutdir, "go.sum"))
+		require.NoError(t, err)
+		return string(b), string(sum)
+	}
+
+	_, goSum := generate()
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_go_mod")
+	saasytesting.CheckFilesInDirsEqual(t, pSvcOutdir, referenceDir, []string{"go.mod"})
+
+	// the go.sum has the checksums of all the modules required, saas-y being replaced
+	sum := strings.Split(strings.TrimSpace(templates.GoSum), "\n")
+	sort.Strings(sum)
+	require.Equal(t, strings.Join(sum, "\n")+"\n", goSum)
+
+	// an existing go.mod is kept, the missing dependencies being appended to it
+	existing := `module example.com/example/services/foo-service
+
+require (
+	github.com/popescu-af/saas-y v1.0.0
+	example.com/example/services/bar-service v1.2.3 // pinned
+)
+
+replace example.com/example/services/bar-service => ../../../bar-service
+`
+	require.NoError(t, ioutil.WriteFile(path.Join(pSvcOutdir, "go.mod"), []byte(existing), 0660))
+
+	// an existing go.sum is kept, the missing checksums being added to it
+	existingSum := "example.com/pinned v1.2.3 h1:pinned=\n" + sum[0] + "\n"
+	require.NoError(t, ioutil.WriteFile(path.Join(pSvcOutdir, "go.sum"), []byte(existingSum), 0660))
+	mergedSum := append([]string{"example.com/pinned v1.2.3 h1:pinned="}, sum...)
+	sort.Strings(mergedSum)
+
+	merged := existing + `
+require example.com/example/shared v0.0.0-00010101000000-000000000000
+
+require example.com/example/services/baz-service v0.0.0-00010101000000-000000000000
+
+replace example.com/example/shared => ../../shared
+
+replace example.com/example/services/baz-service => ../baz-service
+
+replace github.com/popescu-af/saas-y => /path/to/saas-y
+`
+	for i := 0; i < 2; i++ {
+		goMod, goSum := generate()
+		require.Equal(t, merged, goMod)
+		require.Equal(t, strings.Join(mergedSum, "\n")+"\n", goSum)
+	}
+}
@@ -0,0 +1,109 @@
+package generator_test
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/internal/generator"
+	"github.com/popescu-af/saas-y/internal/model"
+	saasytesting "github.com/popescu-af/saas-y/internal/testing"
+)
+
+func TestGeneratedOpenAPI(t *testing.T) {
+	one, zero, hundred, ten := 1, 0.0, 100.0, 10
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{
+			Name:          "foo-service",
+			RepositoryURL: "foo-service",
+			Port:          "80",
+		},
+		API: []model.API{
+			{
+				Path: "/users",
+				Methods: map[string]model.Method{
+					"get_users": {
+						Type: model.GET,
+						QueryParams: []model.Variable{
+							{Name: "status", Type: "user_status", Required: true},
+							{Name: "ids", Type: "[]uuid"},
+							{Name: "max_age", Type: "duration"},
+						},
+						ReturnType: "[]user",
+						Auth:       model.AuthOptional,
+					},
+					"create_user": {
+						Type:         model.POST,
+						HeaderParams: []model.Variable{{Name: "request_id", Type: "string", Required: true}},
+						InputType:    "user",
+						ReturnType:   "user",
+					},
+				},
+			},
+			{
+				Path: "/users/{id:uuid}/tags/{tag:string}",
+				Methods: map[string]model.Method{
+					"delete_user_tag": {Type: model.DELETE},
+				},
+			},
+			{
+				Path: "/events",
+				Methods: map[string]model.Method{
+					"get_event_counts": {Type: model.GET, ReturnType: "map<string,uint>", Auth: model.AuthNone},
+				},
+			},
+			{
+				Path: "/events/stream",
+				Methods: map[string]model.Method{
+					"watch_events": {Type: model.WS, QueryParams: []model.Variable{{Name: "since", Type: "time"}}},
+				},
+			},
+		},
+		Structs: []model.Struct{
+			{
+				Name: "user",
+				Fields: []model.Variable{
+					{Name: "name", Type: "string", Required: true, MinLength: &one, Pattern: "^[a-z]+$"},
+					{Name: "age", Type: "uint", Optional: true, Max: &hundred},
+					{Name: "status", Type: "user_status", Required: true},
+					{Name: "scores", Type: "[]float", MaxLength: &ten, Min: &zero},
+					{Name: "labels", Type: "map<string,string>", Optional: true},
+					{Name: "avatar", Type: "bytes"},
+					{Name: "created_at", Type: "time"},
+					{Name: "home", Type: "*address"},
+				},
+			},
+		},
+		Enums: []model.Enum{
+			{Name: "user_status", Values: []string{"active", "blocked"}},
+		},
+		Errors: []model.ErrorKind{
+			{Name: "user_not_found", Status: 404},
+			{Name: "tag_not_found", Status: 404},
+			{Name: "user_blocked", Status: 403, Payload: "user"},
+		},
+		SharedStructs: []model.Struct{
+			{
+				Name: "address",
+				Fields: []model.Variable{
+					{Name: "street", Type: "string"},
+				},
+			},
+		},
+		SharedRepositoryURL: "foo/shared",
+		Auth:                model.Auth{Type: model.AuthTypeAPIKey, Header: "X-Foo-Key"},
+	}
+
+	generator.Init()
+
+	pOutdir, err := generateServiceFiles(svc)
+	require.NoError(t, err)
+	defer os.RemoveAll(pOutdir)
+
+	pOutdir = path.Join(pOutdir, "services", svc.Name)
+	referenceDir := path.Join(saasytesting.GetTestingCommonDirectory(), "..", "generator", "testdata", "generated_openapi")
+	saasytesting.CheckFilesInDirsEqual(t, path.Join(pOutdir, "api"), referenceDir, []string{"openapi.json"})
+}
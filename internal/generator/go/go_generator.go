@@ -2,14 +2,24 @@ package gengo
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"io/ioutil"
-	"os/exec"
+	"os"
 	"path"
 	"regexp"
+	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 
+	saasy "github.com/popescu-af/saas-y"
 	"github.com/popescu-af/saas-y/internal/generator"
 	"github.com/popescu-af/saas-y/internal/generator/go/templates"
 )
@@ -43,12 +53,16 @@ func (g *Generator) GetTemplate(name string) string {
 	switch name {
 	case "api":
 		return templates.APIDefinition
+	case "api_errors":
+		return templates.APIErrors
 	case "impl":
 		return templates.Impl
 	case "client":
 		return templates.Client
 	case "dockerfile":
 		return templates.Dockerfile
+	case "enum":
+		return templates.Enum
 	case "env":
 		return templates.Env
 	case "errors":
@@ -61,6 +75,8 @@ func (g *Generator) GetTemplate(name string) string {
 		return templates.HTTPWrapper
 	case "main":
 		return templates.Main
+	case "middleware":
+		return templates.Middleware
 	case "struct":
 		return templates.Struct
 	}
@@ -69,110 +85,281 @@ func (g *Generator) GetTemplate(name string) string {
 	return ""
 }
 
-// CodeFormatter returns the code formatter for go code.
-func (g *Generator) CodeFormatter(path string) (st generator.SymbolTable, err error) {
-	fmt.Println("Formatting", path)
-
-	var out, errout bytes.Buffer
-
-	cmd := exec.Command("golint", path)
-	cmd.Stdout = &out
-	cmd.Stderr = &errout
-
-	err = cmd.Run()
+// CodeFormatter formats the go code at the given path like gofmt,
+// dropping the imports of the packages the code does not use.
+func (g *Generator) CodeFormatter(filePath string) (err error) {
+	src, err := ioutil.ReadFile(filePath)
 	if err != nil {
-		fmt.Println(errout.String())
 		return
 	}
 
-	b, err := ioutil.ReadFile(path)
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("generated invalid go code in %s: %v", filePath, err)
+	}
+
+	b, err := format.Source(removeUnusedImports(fset, f, src))
 	if err != nil {
 		return
 	}
+	return ioutil.WriteFile(filePath, b, 0660)
+}
 
-	st = make(generator.SymbolTable)
-	contents := string(b)
-	reLint := regexp.MustCompile(`(var|method|method parameter|struct field|type) ([a-zA-Z0-9_]+) should be ([a-zA-Z0-9]+)`)
+// removeUnusedImports removes the lines importing the packages the file does
+// not refer to, along with the import declarations left empty.
+func removeUnusedImports(fset *token.FileSet, f *ast.File, src []byte) []byte {
+	used := make(map[string]bool)
+	collectPackages(f, used)
 
-	for _, matchLint := range reLint.FindAllStringSubmatch(out.String(), -1) {
-		old := matchLint[2]
-		new := matchLint[3]
+	// lines returns the range of the whole lines spanned by a node
+	lines := func(n ast.Node) [2]int {
+		start := fset.Position(n.Pos()).Offset
+		end := fset.Position(n.End()).Offset
+		start = bytes.LastIndexByte(src[:start], '\n') + 1
+		if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+			end += i + 1
+		} else {
+			end = len(src)
+		}
+		return [2]int{start, end}
+	}
 
-		st[old] = new
-		reDefinition := regexp.MustCompile(old + `([^"'=])`)
+	var unused [][2]int
+	for _, d := range f.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
 
-		for _, matchDefinition := range reDefinition.FindAllStringSubmatch(contents, -1) {
-			contents = strings.ReplaceAll(contents, old+matchDefinition[1], new+matchDefinition[1])
+		var specs [][2]int
+		for _, s := range gd.Specs {
+			name := importName(s.(*ast.ImportSpec))
+			if name != "_" && name != "." && !used[name] {
+				specs = append(specs, lines(s))
+			}
 		}
+		if len(specs) == len(gd.Specs) {
+			specs = [][2]int{lines(gd)}
+		}
+		unused = append(unused, specs...)
+	}
+
+	// the ranges are removed from the last one, keeping the offsets of the others valid
+	for i := len(unused) - 1; i >= 0; i-- {
+		src = append(src[:unused[i][0]:unused[i][0]], src[unused[i][1]:]...)
+	}
+	return src
+}
+
+// importName returns the name an import is referred to by, assuming the package
+// is named after the last element of its path, versions aside, e.g. gopkg.in/yaml.v3
+// and github.com/go-redis/redis/v8 are referred to as yaml and redis.
+func importName(imp *ast.ImportSpec) string {
+	if imp.Name != nil {
+		return imp.Name.Name
 	}
 
-	out.Reset()
-	errout.Reset()
+	p, _ := strconv.Unquote(imp.Path.Value)
+	if base := path.Base(p); len(base) > 1 && base[0] == 'v' && isNumber(base[1:]) {
+		p = path.Dir(p)
+	}
+	name := path.Base(p)
+	if i := strings.LastIndex(name, ".v"); i > 0 && isNumber(name[i+2:]) {
+		name = name[:i]
+	}
+	return name
+}
 
-	cmd = exec.Command("goimports")
-	cmd.Stdin = bytes.NewBufferString(contents)
-	cmd.Stdout = &out
-	cmd.Stderr = &errout
+func isNumber(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
 
-	err = cmd.Run()
+// GenerateProject creates the go.mod and go.sum files of the module. The module requires
+// the versions of the packages used by the generated code known to work with it, and finds
+// the given dependencies in their directories. An existing go.mod is kept, only the requirements
+// and replacements of the dependencies missing from it are appended to it, and the missing
+// checksums are added to an existing go.sum.
+func (g *Generator) GenerateProject(name, dir string, dependencies []generator.LocalDependency) (err error) {
+	requirement, err := saasyRequirement()
 	if err != nil {
-		fmt.Printf("%s", errout.String())
 		return
 	}
+	replacements := dependencies
+	if requirement.Source != "" {
+		replacements = append(replacements[:len(replacements):len(replacements)], generator.LocalDependency{Name: saasyModule, Path: requirement.Source})
+	}
 
-	err = ioutil.WriteFile(path, out.Bytes(), 0660)
-	return
+	if err = generateGoMod(name, path.Join(dir, "go.mod"), requirement.Version, dependencies, replacements); err != nil {
+		return
+	}
+	return generateGoSum(path.Join(dir, "go.sum"), requirement)
 }
 
-// GenerateProject creates project-specific files.
-func (g *Generator) GenerateProject(name, path string) (err error) {
-	err = g.createGoModFile(name, path)
+// generateGoMod creates the go.mod file at the given path, or merges the dependencies
+// and replacements into the existing one.
+func generateGoMod(name, goModPath, saasyVersion string, dependencies, replacements []generator.LocalDependency) (err error) {
+	existing, err := ioutil.ReadFile(goModPath)
+	if err == nil {
+		return ioutil.WriteFile(goModPath, mergeGoMod(existing, dependencies, replacements), 0660)
+	} else if !os.IsNotExist(err) {
+		return
+	}
 
-	// TODO:
-	// Makefile
-	// Dockerfile
+	var b bytes.Buffer
+	err = template.Must(template.New("go.mod").Parse(templates.GoMod)).Execute(&b, struct {
+		Name               string
+		SaasyVersion       string
+		PlaceholderVersion string
+		Dependencies       []generator.LocalDependency
+		Replacements       []generator.LocalDependency
+	}{name, saasyVersion, placeholderVersion, dependencies, replacements})
+	if err != nil {
+		return
+	}
+	return ioutil.WriteFile(goModPath, b.Bytes(), 0660)
+}
 
-	return
+// generateGoSum creates the go.sum file at the given path, with the checksums of the modules
+// required by the generated code, or adds those it lacks to the existing one.
+func generateGoSum(goSumPath string, requirement saasyInfo) (err error) {
+	existing, err := ioutil.ReadFile(goSumPath)
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+
+	lines := strings.Split(string(existing)+templates.GoSum, "\n")
+	if requirement.Sum != "" {
+		lines = append(lines,
+			fmt.Sprintf("%s %s %s", saasyModule, requirement.Version, requirement.Sum),
+			fmt.Sprintf("%s %s/go.mod %s", saasyModule, requirement.Version, goModSum(saasy.GoMod)))
+	}
+
+	seen := make(map[string]bool)
+	sum := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" && !seen[line] {
+			seen[line] = true
+			sum = append(sum, line)
+		}
+	}
+	sort.Strings(sum)
+	return ioutil.WriteFile(goSumPath, []byte(strings.Join(sum, "\n")+"\n"), 0660)
 }
 
-func (g *Generator) createGoModFile(name, path string) (err error) {
-	var out, errout bytes.Buffer
+// goModSum returns the checksum recorded in go.sum files for a go.mod file of the given content.
+func goModSum(content []byte) string {
+	file := sha256.Sum256(content)
+	summary := sha256.Sum256([]byte(fmt.Sprintf("%x  go.mod\n", file)))
+	return "h1:" + base64.StdEncoding.EncodeToString(summary[:])
+}
 
-	cmd := exec.Command("go", "env")
-	cmd.Stdout = &out
-	cmd.Stderr = &errout
-	if err = cmd.Run(); err != nil {
-		fmt.Printf("%s", errout.String())
-		return
+// mergeGoMod appends to the content of a go.mod file the requirements of the given
+// dependencies and the given replacements which it lacks.
+func mergeGoMod(content []byte, dependencies, replacements []generator.LocalDependency) []byte {
+	required, replaced := goModDirectives(content)
+
+	var b bytes.Buffer
+	for _, d := range dependencies {
+		if !required[d.Name] {
+			fmt.Fprintf(&b, "\nrequire %s %s\n", d.Name, placeholderVersion)
+		}
 	}
+	for _, r := range replacements {
+		if !replaced[r.Name] {
+			fmt.Fprintf(&b, "\nreplace %s => %s\n", r.Name, r.Path)
+		}
+	}
+	if b.Len() == 0 {
+		return content
+	}
+
+	merged := bytes.TrimRight(content, "\n")
+	return append(append(merged[:len(merged):len(merged)], '\n'), b.Bytes()...)
+}
+
+// goModDirectives returns the modules required and replaced by the content of a go.mod file,
+// whether in single line directives or in blocks.
+func goModDirectives(content []byte) (required, replaced map[string]bool) {
+	required, replaced = make(map[string]bool), make(map[string]bool)
+
+	block := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
 
-	cmd = exec.Command("go", "mod", "init", name)
-	cmd.Env = []string{"GO111MODULE=on"}
-	cmd.Dir = path
-	cmd.Stdout = &out
-	cmd.Stderr = &errout
-
-	neededVars := []string{"GOCACHE", "GOPATH"}
-	lines := strings.Split(out.String(), "\n")
-	for _, l := range lines {
-		tokens := strings.Split(l, "=")
-		for _, v := range neededVars {
-			if tokens[0] == v {
-				var unquoted string
-				if unquoted, err = strconv.Unquote(tokens[1]); err != nil {
-					return
-				}
-				cmd.Env = append(cmd.Env, v+"="+unquoted)
-				break
+		directive := block
+		if block == "" {
+			directive, fields = fields[0], fields[1:]
+			if len(fields) == 1 && fields[0] == "(" {
+				block = directive
+				continue
 			}
+		} else if fields[0] == ")" {
+			block = ""
+			continue
+		}
+		if len(fields) == 0 {
+			continue
 		}
-	}
-	out.Reset()
-	errout.Reset()
 
-	if err = cmd.Run(); err != nil {
-		fmt.Printf("%s", errout.String())
-		err = nil
+		module := strings.Trim(fields[0], "\"`")
+		switch directive {
+		case "require":
+			required[module] = true
+		case "replace":
+			replaced[module] = true
+		}
 	}
 	return
 }
+
+// ProjectFiles returns the files created by GenerateProject, which are kept once created,
+// only merging the missing dependencies into them.
+func (g *Generator) ProjectFiles() []string {
+	return []string{"go.mod", "go.sum"}
+}
+
+// saasyModule is the module of saas-y, holding the packages used by the generated code.
+const saasyModule = "github.com/popescu-af/saas-y"
+
+// saasySourceVariable is the environment variable giving the directory of the saas-y source
+// which the generated modules use instead of a released saas-y, e.g. to develop saas-y itself.
+const saasySourceVariable = "SAASY_SOURCE"
+
+// placeholderVersion is the version at which the modules replaced by their directories are required.
+const placeholderVersion = "v0.0.0-00010101000000-000000000000"
+
+// pseudoVersion matches the suffix of the versions the go command assigns to untagged commits.
+var pseudoVersion = regexp.MustCompile(`[-.][0-9]{14}-[0-9a-f]{12}$`)
+
+// saasyInfo is the requirement of saas-y by the generated modules.
+type saasyInfo struct {
+	Version string // version of saas-y
+	Sum     string // checksum of the module of this version, empty if replaced by Source
+	Source  string // directory replacing saas-y, empty for a released version
+}
+
+// saasyRequirement returns the requirement of saas-y by the generated code, on the release
+// generating it, or on the source in the directory given by the SAASY_SOURCE environment
+// variable, if set. A saas-y which is neither released nor given its source is an error,
+// as the generated modules would require a version of it they cannot download.
+func saasyRequirement() (saasyInfo, error) {
+	if source := os.Getenv(saasySourceVariable); source != "" {
+		return saasyInfo{Version: placeholderVersion, Source: source}, nil
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Path == saasyModule && info.Main.Sum != "" &&
+		strings.HasPrefix(info.Main.Version, "v") && !strings.Contains(info.Main.Version, "+") &&
+		!pseudoVersion.MatchString(info.Main.Version) {
+		return saasyInfo{Version: info.Main.Version, Sum: info.Main.Sum}, nil
+	}
+	return saasyInfo{}, fmt.Errorf("saas-y is not a released version, which the generated modules could require, "+
+		"install one with go install %s@<version> or set %s to the directory of the saas-y source", saasyModule, saasySourceVariable)
+}
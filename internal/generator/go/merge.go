@@ -0,0 +1,235 @@
+package gengo
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"strings"
+)
+
+// implementationType is the receiver of the API methods in the hand-written code.
+const implementationType = "Implementation"
+
+// MergeCode merges freshly generated go code into the hand-written file at the given path.
+// Declarations missing from the file, e.g. the stubs of methods newly added to the spec,
+// are appended to it together with the imports they need, everything else being kept as
+// written. Implementation methods no longer generated and functions whose signature
+// differs from the generated one are reported, to be updated by hand.
+func (g *Generator) MergeCode(filePath string, generated []byte) (report []string, err error) {
+	written, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return
+	}
+
+	wfset := token.NewFileSet()
+	wfile, err := parser.ParseFile(wfset, filePath, written, parser.ParseComments)
+	if err != nil {
+		err = fmt.Errorf("cannot merge generated code into %s, fix it first: %v", filePath, err)
+		return
+	}
+
+	gfset := token.NewFileSet()
+	gfile, err := parser.ParseFile(gfset, "", generated, parser.ParseComments)
+	if err != nil {
+		return
+	}
+
+	writtenDecls := declarations(wfset, wfile)
+	generatedDecls := declarations(gfset, gfile)
+
+	var added []string
+	usedPackages := make(map[string]bool)
+	for _, d := range generatedDecls.sorted() {
+		w, ok := writtenDecls[d.key]
+		if !ok {
+			added = append(added, string(generated[gfset.Position(d.pos).Offset:gfset.Position(d.end).Offset]))
+			collectPackages(d.node, usedPackages)
+			continue
+		}
+		if d.signature != w.signature {
+			report = append(report, fmt.Sprintf("%s has the signature %s, expected %s", d.key, w.signature, d.signature))
+		}
+	}
+
+	generatedKeys := make(map[string]bool)
+	for _, d := range generatedDecls {
+		generatedKeys[d.key] = true
+	}
+	for _, w := range writtenDecls.sorted() {
+		if strings.HasPrefix(w.key, implementationType+".") && !generatedKeys[w.key] {
+			report = append(report, fmt.Sprintf("%s is implemented but no longer in the spec", w.key))
+		}
+	}
+
+	if len(added) == 0 {
+		return
+	}
+
+	merged := insertImports(wfset, wfile, written, missingImports(wfile, gfile, usedPackages))
+	merged = append(bytes.TrimRight(merged, "\n"), '\n')
+	for _, a := range added {
+		merged = append(merged, '\n')
+		merged = append(merged, a...)
+		merged = append(merged, '\n')
+	}
+
+	if merged, err = format.Source(merged); err != nil {
+		return
+	}
+	err = ioutil.WriteFile(filePath, merged, 0660)
+	return
+}
+
+// declaration is a top-level function or type declaration.
+type declaration struct {
+	key       string // function name, prefixed by the receiver type for methods, or type name
+	signature string // parameter and result types of functions
+	pos, end  token.Pos
+	node      ast.Node
+	index     int
+}
+
+type declarationMap map[string]declaration
+
+func (m declarationMap) sorted() []declaration {
+	result := make([]declaration, len(m))
+	for _, d := range m {
+		result[d.index] = d
+	}
+	return result
+}
+
+// declarations returns the top-level functions and types of a file, keyed and in file order.
+func declarations(fset *token.FileSet, f *ast.File) declarationMap {
+	m := make(declarationMap)
+	add := func(d declaration, doc *ast.CommentGroup) {
+		if doc != nil {
+			d.pos = doc.Pos()
+		}
+		d.index = len(m)
+		m[d.key] = d
+	}
+
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			key := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				key = receiverTypeName(decl.Recv.List[0].Type) + "." + key
+			}
+			add(declaration{
+				key:       key,
+				signature: signature(fset, decl.Type),
+				pos:       decl.Pos(),
+				end:       decl.End(),
+				node:      decl,
+			}, decl.Doc)
+		case *ast.GenDecl:
+			if decl.Tok != token.TYPE || len(decl.Specs) != 1 {
+				continue
+			}
+			add(declaration{
+				key:  decl.Specs[0].(*ast.TypeSpec).Name.Name,
+				pos:  decl.Pos(),
+				end:  decl.End(),
+				node: decl,
+			}, decl.Doc)
+		}
+	}
+	return m
+}
+
+func receiverTypeName(t ast.Expr) string {
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if ident, ok := t.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// signature prints the parameter and result types of a function, ignoring their names.
+func signature(fset *token.FileSet, t *ast.FuncType) string {
+	fieldTypes := func(fields *ast.FieldList) string {
+		if fields == nil {
+			return ""
+		}
+		var types []string
+		for _, f := range fields.List {
+			var b bytes.Buffer
+			printer.Fprint(&b, fset, f.Type)
+			for i := 0; i < len(f.Names) || i == 0; i++ {
+				types = append(types, b.String())
+			}
+		}
+		return strings.Join(types, ", ")
+	}
+
+	s := "(" + fieldTypes(t.Params) + ")"
+	if results := fieldTypes(t.Results); results != "" {
+		s += " (" + results + ")"
+	}
+	return s
+}
+
+// collectPackages records the names of the packages referenced by a declaration.
+func collectPackages(n ast.Node, packages map[string]bool) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok && ident.Obj == nil {
+				packages[ident.Name] = true
+			}
+		}
+		return true
+	})
+}
+
+// missingImports returns the imports of the generated file which are used
+// by the given packages and are missing from the hand-written file.
+func missingImports(written, generated *ast.File, packages map[string]bool) (imports []string) {
+	present := make(map[string]bool)
+	for _, imp := range written.Imports {
+		present[imp.Path.Value] = true
+	}
+
+	for _, imp := range generated.Imports {
+		if !packages[importName(imp)] || present[imp.Path.Value] {
+			continue
+		}
+
+		spec := imp.Path.Value
+		if imp.Name != nil {
+			spec = imp.Name.Name + " " + spec
+		}
+		imports = append(imports, spec)
+	}
+	return
+}
+
+// insertImports adds the given import specs to the first import declaration of the
+// file, or to a new one following the package clause if there is none to add to.
+func insertImports(fset *token.FileSet, f *ast.File, src []byte, imports []string) []byte {
+	if len(imports) == 0 {
+		return src
+	}
+
+	var at int
+	var text string
+	if len(f.Imports) > 0 && f.Decls[0].(*ast.GenDecl).Rparen.IsValid() {
+		at = fset.Position(f.Decls[0].(*ast.GenDecl).Rparen).Offset
+		text = "\t" + strings.Join(imports, "\n\t") + "\n"
+	} else {
+		at = fset.Position(f.Name.End()).Offset
+		text = "\n\nimport (\n\t" + strings.Join(imports, "\n\t") + "\n)"
+	}
+
+	result := append([]byte{}, src[:at]...)
+	result = append(result, text...)
+	return append(result, src[at:]...)
+}
@@ -8,9 +8,12 @@ const APIDefinition = `package exports
 {{end}}{{end}}
 
 import (
+	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/popescu-af/saas-y/pkg/connection"
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
 )
 
 // API defines the operations supported by the {{.Name}} service.
@@ -19,13 +22,13 @@ type API interface {
 		// {{$a.Path}}
 		{{range $mname, $method := $a.Methods}}
 		{{- if eq $method.Type "WS" -}}
-			{{with $fname := $mname | capitalize -}}
-			{{printf "%s%s%s" "New" $fname "ChannelListener" | symbolize}}() (connection.ChannelListener, error)
+			{{with $fname := $mname | exported -}}
+			{{printf "%s%s%s" "New" $fname "ChannelListener"}}(context.Context) (connection.ChannelListener, error)
 			{{- end}}
 		{{else -}}
-			{{- $mname | capitalize | symbolize}}(
+			{{- $mname | exported}}(context.Context,
 				{{- if $method.InputType -}}
-					*{{- $method.InputType | capitalize | symbolize}},
+					*{{- $method.InputType | typeName}},
 				{{- end -}}
 				{{- if $a.Path | pathHasParameters -}}
 					{{- with $params := $a.Path | pathParameters -}}
@@ -49,8 +52,10 @@ type API interface {
 			)
 			{{- if eq $method.ReturnType "" -}}
 				error
+			{{- else if $method.ReturnType | isContainerType -}}
+				({{$method.ReturnType | typeName}}, error)
 			{{- else -}}
-				(*{{$method.ReturnType | capitalize | symbolize}}, error)
+				(*{{$method.ReturnType | typeName}}, error)
 			{{- end}}
 		{{end -}}
 		{{end -}}
@@ -63,13 +68,13 @@ type APIClient interface {
 		// {{$a.Path}}
 		{{range $mname, $method := $a.Methods}}
 		{{- if eq $method.Type "WS" -}}
-			{{with $fname := $mname | capitalize -}}
-			{{printf "%s%s%s" "New" $fname "Client" | symbolize}}(connection.ChannelListener) (*connection.FullDuplex, error)
+			{{with $fname := $mname | exported -}}
+			{{printf "%s%s%s" "New" $fname "Client"}}(context.Context, connection.ChannelListener) (*connection.FullDuplex, error)
 			{{- end}}
 		{{else -}}
-			{{- $mname | capitalize | symbolize}}(
+			{{- $mname | exported}}(context.Context,
 				{{- if $method.InputType -}}
-					*{{- $method.InputType | capitalize | symbolize}},
+					*{{- $method.InputType | typeName}},
 				{{- end -}}
 				{{- if $a.Path | pathHasParameters -}}
 					{{- with $params := $a.Path | pathParameters -}}
@@ -93,8 +98,10 @@ type APIClient interface {
 			)
 			{{- if eq $method.ReturnType "" -}}
 				error
+			{{- else if $method.ReturnType | isContainerType -}}
+				({{$method.ReturnType | typeName}}, error)
 			{{- else -}}
-				(*{{$method.ReturnType | capitalize | symbolize}}, error)
+				(*{{$method.ReturnType | typeName}}, error)
 			{{- end}}
 		{{end -}}
 		{{end -}}
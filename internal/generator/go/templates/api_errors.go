@@ -0,0 +1,105 @@
+package templates
+
+// APIErrors is the template for the go definition of the errors returned by the API.
+const APIErrors = `package exports
+
+import (
+	"encoding/json"
+
+	"github.com/popescu-af/saas-y/pkg/validation"
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
+)
+
+// Kinds of the errors sent by the generated code itself.
+const (
+	ValidationErrorKind   = "validation"
+	UnauthorizedErrorKind = "unauthorized"
+	InternalErrorKind     = "internal"
+)
+
+// Error is the JSON envelope of the errors sent by the {{.Name}} service,
+// returned as is by the client for the kinds not declared in the spec.
+type Error struct {
+	Kind    string          ` + "`" + `json:"error"` + "`" + `
+	Message string          ` + "`" + `json:"message"` + "`" + `
+	Payload json.RawMessage ` + "`" + `json:"payload,omitempty"` + "`" + `
+	Status  int             ` + "`" + `json:"-"` + "`" + `
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Typed returns the error of the kind of the envelope, with its payload: one of the
+// errors declared in the spec or a validation error. Other kinds are left as they are.
+func (e *Error) Typed() error {
+	switch e.Kind {
+	case ValidationErrorKind:
+		v := &validation.Error{}
+		if err := json.Unmarshal(e.Payload, v); err == nil {
+			return v
+		}
+	{{- range .Errors}}
+	case "{{.Name}}":
+		{{if .Payload -}}
+		t := &{{.Name | exported}}Error{Message: e.Message}
+		if len(e.Payload) > 0 {
+			if err := json.Unmarshal(e.Payload, &t.Payload); err != nil {
+				return e
+			}
+		}
+		return t
+		{{- else -}}
+		return &{{.Name | exported}}Error{Message: e.Message}
+		{{- end}}
+	{{- end}}
+	}
+	return e
+}
+
+// TypedError is implemented by the errors declared in the spec, which the
+// implementation returns to have them sent with their status and payload.
+type TypedError interface {
+	error
+	Kind() string
+	Status() int
+	ErrorPayload() interface{}
+}
+{{range .Errors}}
+// {{.Name | exported}}Error is the error of kind {{.Name}}, sent with status {{.Status}}.
+type {{.Name | exported}}Error struct {
+	Message string
+	{{- if .Payload}}
+	Payload *{{.Payload | typeName}}
+	{{- end}}
+}
+
+// New{{.Name | exported}}Error creates a {{.Name | exported}}Error.
+func New{{.Name | exported}}Error(message string{{if .Payload}}, payload *{{.Payload | typeName}}{{end}}) *{{.Name | exported}}Error {
+	return &{{.Name | exported}}Error{Message: message{{if .Payload}}, Payload: payload{{end}}}
+}
+
+func (e *{{.Name | exported}}Error) Error() string {
+	return e.Message
+}
+
+// Kind returns the kind of the error, {{.Name}}.
+func (e *{{.Name | exported}}Error) Kind() string {
+	return "{{.Name}}"
+}
+
+// Status returns the HTTP status of the error, {{.Status}}.
+func (e *{{.Name | exported}}Error) Status() int {
+	return {{.Status}}
+}
+
+// ErrorPayload returns the payload of the error, if any.
+func (e *{{.Name | exported}}Error) ErrorPayload() interface{} {
+	{{- if .Payload}}
+	if e.Payload != nil {
+		return e.Payload
+	}
+	{{- end}}
+	return nil
+}
+{{end}}`
@@ -9,41 +9,205 @@ const Client = `package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/popescu-af/saas-y/pkg/auth"
 	"github.com/popescu-af/saas-y/pkg/connection"
+	"github.com/popescu-af/saas-y/pkg/retry"
 
 	"{{.RepositoryURL}}/pkg/exports"
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
 )
 
-{{with $cleanName := .Name | cleanName | capitalize}}
+{{with $cleanName := .Name | exported}}
 // {{$cleanName}}Client is the structure that encompasses a {{$.Name}} client.
 type {{$cleanName}}Client struct {
 	connectionManager *connection.FullDuplexManager
-	remoteAddress string
+	baseURL string
+	httpClient *http.Client
+	timeout time.Duration
+	retryPolicy *retry.Policy
+	useTLS bool
+	tlsConfig *tls.Config
+	{{- if $.Auth.Enabled}}
+	credentials string
+	{{- end}}
+}
+
+// Option configures a {{$cleanName}}Client.
+type Option func(*{{$cleanName}}Client)
+
+// WithHTTPClient makes the client send the requests with the given HTTP client,
+// instead of http.DefaultClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout limits the time of each attempt to send a request,
+// on top of the deadlines of the contexts the requests are sent with.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.timeout = timeout
+	}
 }
 
+// WithRetry makes the client retry the requests failing temporarily as the given policy says,
+// e.g. retry.DefaultPolicy. Only the requests with idempotent methods, e.g. GET, are retried.
+func WithRetry(policy retry.Policy) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.retryPolicy = &policy
+	}
+}
+
+// WithTLS makes the client connect to the service over TLS, i.e. with https and wss, using
+// the given configuration, or the default one if nil. The configuration applies to HTTP clients
+// set with WithHTTPClient only if their transport is an *http.Transport.
+func WithTLS(config *tls.Config) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.useTLS = true
+		c.tlsConfig = config
+	}
+}
+
+// WithBaseURL sets the URL the paths of the API are relative to, e.g. https://example.com/foo,
+// instead of the one made of the remote address. Websocket connections use it with the ws or wss scheme.
+func WithBaseURL(baseURL string) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+{{- if eq $.Auth.Type "api_key"}}
+// WithAPIKey makes the client authenticate the requests with the given API key. Without it,
+// the requests are authenticated with the credentials of the caller of the method being served,
+// if any, as held by the contexts the requests are sent with.
+func WithAPIKey(key string) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.credentials = key
+	}
+}
+{{- else if eq $.Auth.Type "jwt"}}
+// WithBearerToken makes the client authenticate the requests with the given JWT. Without it,
+// the requests are authenticated with the credentials of the caller of the method being served,
+// if any, as held by the contexts the requests are sent with.
+func WithBearerToken(token string) Option {
+	return func(c *{{$cleanName}}Client) {
+		c.credentials = token
+	}
+}
+{{- end}}
+
 // New{{$cleanName}}Client creates a new instance of {{$.Name}} client.
-func New{{$cleanName}}Client(remoteAddress string) *{{$cleanName}}Client {
-	return &{{$cleanName}}Client{
+func New{{$cleanName}}Client(remoteAddress string, options ...Option) *{{$cleanName}}Client {
+	c := &{{$cleanName}}Client{
 		connectionManager: connection.NewFullDuplexManager(),
-		remoteAddress: remoteAddress,
+		httpClient: http.DefaultClient,
+	}
+	for _, o := range options {
+		o(c)
 	}
+
+	if c.baseURL == "" {
+		c.baseURL = "http://" + remoteAddress
+		if c.useTLS {
+			c.baseURL = "https://" + remoteAddress
+		}
+	}
+
+	if c.timeout > 0 || c.tlsConfig != nil {
+		// a copy, not to change the HTTP client of others
+		httpClient := *c.httpClient
+		if c.timeout > 0 {
+			httpClient.Timeout = c.timeout
+		}
+		if c.tlsConfig != nil {
+			transport, ok := httpClient.Transport.(*http.Transport)
+			if httpClient.Transport == nil {
+				transport, ok = http.DefaultTransport.(*http.Transport)
+			}
+			if ok {
+				transport = transport.Clone()
+				transport.TLSClientConfig = c.tlsConfig
+				httpClient.Transport = transport
+			}
+		}
+		c.httpClient = &httpClient
+	}
+	return c
+}
+
+{{- if $.Auth.Enabled}}
+// authenticate sets the credentials of the client in the given headers, or else
+// the ones of the principal held by the context, if any.
+func (c *{{$cleanName}}Client) authenticate(ctx context.Context, header http.Header) {
+	credentials := c.credentials
+	if principal, ok := auth.FromContext(ctx); ok && credentials == "" {
+		credentials = principal.Credentials
+	}
+	if credentials == "" {
+		return
+	}
+	{{- if eq $.Auth.Type "jwt"}}
+	header.Set("Authorization", "Bearer "+credentials)
+	{{- else}}
+	header.Set({{if $.Auth.Header}}"{{$.Auth.Header}}"{{else}}auth.DefaultAPIKeyHeader{{end}}, credentials)
+	{{- end}}
+}
+{{- end}}
+
+// do sends a request, retrying it if configured so.
+func (c *{{$cleanName}}Client) do(request *http.Request) (*http.Response, error) {
+	{{- if $.Auth.Enabled}}
+	c.authenticate(request.Context(), request.Header)
+	{{- end}}
+	if c.retryPolicy != nil {
+		return c.retryPolicy.Do(c.httpClient, request)
+	}
+	return c.httpClient.Do(request)
+}
+
+// decodeError returns the error sent by the service in a response with a status other than 200,
+// typed as declared in the spec. Responses without an error envelope get a generic error.
+func decodeError(response *http.Response) error {
+	e := &exports.Error{}
+	if err := json.NewDecoder(response.Body).Decode(e); err != nil || e.Kind == "" {
+		return fmt.Errorf("%s %s failed with status code %d", response.Request.Method, response.Request.URL, response.StatusCode)
+	}
+
+	e.Status = response.StatusCode
+	return e.Typed()
 }
 
 {{range $a := $.API}}
 {{range $mname, $method := $a.Methods}}
 {{if eq $method.Type "WS"}}
-// New{{$mname | capitalize}}Client creates a client for websocket at the path '{{$a.Path}}'.
+// New{{$mname | exported}}Client creates a client for websocket at the path '{{$a.Path}}'.
 // The caller is responsible to close the returned websocket channel when done.
-func (c *{{$cleanName}}Client) New{{$mname | capitalize}}Client(listener connection.ChannelListener) (*connection.FullDuplex, error) {
-	u := url.URL{Scheme: "ws", Host: c.remoteAddress, Path: "{{$a.Path}}"}
-	conn, err := connection.NewWebSocketClient(u, listener)
+func (c *{{$cleanName}}Client) New{{$mname | exported}}Client(ctx context.Context, listener connection.ChannelListener) (*connection.FullDuplex, error) {
+	u, err := url.Parse(c.baseURL + "{{$a.Path}}")
+	if err != nil {
+		return nil, err
+	}
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+
+	options := connection.WebSocketClientOptions{TLSConfig: c.tlsConfig}
+	{{- if $.Auth.Enabled}}
+	options.Header = make(http.Header)
+	c.authenticate(ctx, options.Header)
+	{{- end}}
+	conn, err := connection.NewWebSocketClientWithOptions(ctx, *u, options, listener)
 	if err != nil {
 		return nil, err
 	}
@@ -51,33 +215,35 @@ func (c *{{$cleanName}}Client) New{{$mname | capitalize}}Client(listener connect
 	return conn, nil
 }
 {{- else -}}
-// {{$mname | capitalize}} is the client function for {{$method.Type}} '{{$a.Path}}'.
-func (c *{{$cleanName}}Client) {{$mname | capitalize}}(
+// {{$mname | exported}} is the client function for {{$method.Type}} '{{$a.Path}}'.
+func (c *{{$cleanName}}Client) {{$mname | exported}}(ctx context.Context,
 	{{- if $method.InputType -}}
-		input *exports.{{$method.InputType | capitalize | symbolize}},
+		input *{{qualifiedTypeName "exports" $method.InputType}},
 	{{- end -}}
 	{{- if $a.Path | pathHasParameters -}}
 		{{- with $params := $a.Path | pathParameters -}}
 			{{- range $pnameidx := $params | indicesParameters -}}
-				{{- index $params $pnameidx}} {{with $ptypeidx := inc $pnameidx}}{{index $params $ptypeidx | typeName}},{{end}}
+				{{- index $params $pnameidx | paramName}} {{with $ptypeidx := inc $pnameidx}}{{index $params $ptypeidx | qualifiedTypeName "exports"}},{{end}}
 			{{- end -}}
 		{{- end -}}
 	{{- end -}}
 	{{- if $method.QueryParams -}}
 		{{- range $method.QueryParams -}}
-			{{- .Name}} {{.Type | typeName}},
+			{{- .Name | paramName}} {{.Type | qualifiedTypeName "exports"}},
 		{{- end -}}
 	{{- end -}}
 	{{- if $method.HeaderParams -}}
 		{{- range $method.HeaderParams -}}
-			{{- .Name}} {{.Type | typeName}},
+			{{- .Name | paramName}} {{.Type | qualifiedTypeName "exports"}},
 		{{- end -}}
 	{{- end -}}
 )
 {{- if eq $method.ReturnType "" -}}
 error {
+{{- else if $method.ReturnType | isContainerType -}}
+({{qualifiedTypeName "exports" $method.ReturnType}}, error) {
 {{- else -}}
-(*exports.{{$method.ReturnType | capitalize | symbolize}}, error) {
+(*{{qualifiedTypeName "exports" $method.ReturnType}}, error) {
 {{- end}}
 	var body io.Reader
 
@@ -91,39 +257,53 @@ error {
 	{{end}}
 
 	{{with $fmtAndArgs := $a.Path | createPathWithParameterValues -}}
-		url := "http://" + c.remoteAddress + fmt.Sprintf("{{index $fmtAndArgs 0}}"{{index $fmtAndArgs 1}})
+		requestURL := c.baseURL + fmt.Sprintf("{{index $fmtAndArgs 0}}"{{index $fmtAndArgs 1}})
 	{{- end}}
-	{{if $method.QueryParams -}}
-		{{- range $i, $p := $method.QueryParams}}
-			{{if eq $i 0 -}}
-				url += fmt.Sprintf("?{{$p.Name}}={{$p.Type | typePlaceholder}}", {{$p.Name}})
+	{{if $method.QueryParams}}
+		query := make(url.Values)
+		{{- range $p := $method.QueryParams}}
+			{{if $p.Type | isArrayType -}}
+				for _, v := range {{$p.Name | paramName}} {
+					query.Add("{{$p.Name}}", fmt.Sprintf("{{$p.Type | typePlaceholder}}", {{paramValue $p.Type "v"}}))
+				}
 			{{- else -}}
-				url += fmt.Sprintf("&{{$p.Name}}={{$p.Type | typePlaceholder}}", {{$p.Name}})
+				query.Set("{{$p.Name}}", fmt.Sprintf("{{$p.Type | typePlaceholder}}", {{paramValue $p.Type ($p.Name | paramName)}}))
 			{{- end}}
 		{{- end}}
+		requestURL += "?" + query.Encode()
 	{{- end}}
 
-	request, err := http.NewRequest("{{$method.Type}}", url, body)
+	request, err := http.NewRequestWithContext(ctx, "{{$method.Type}}", requestURL, body)
+	if err != nil {
+		return {{if ne $method.ReturnType ""}}nil,{{end}} err
+	}
 	{{- if $method.HeaderParams -}}
 		{{range $method.HeaderParams}}
-			request.Header.Set("{{.Name}}", fmt.Sprintf("{{.Type | typePlaceholder}}", {{.Name}}))
+			request.Header.Set("{{.Name}}", fmt.Sprintf("{{.Type | typePlaceholder}}", {{paramValue .Type (.Name | paramName)}}))
 		{{- end}}
 	{{- end}}
 
-	response, err := http.DefaultClient.Do(request)
+	response, err := c.do(request)
 	if err != nil {
 		return {{if ne $method.ReturnType ""}}nil,{{end}} err
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return {{if ne $method.ReturnType ""}}nil,{{end}} fmt.Errorf("{{$method.Type}} %s failed with status code %d", url, response.StatusCode)
+		return {{if ne $method.ReturnType ""}}nil,{{end}} decodeError(response)
 	}
 
 	{{if eq $method.ReturnType "" -}}
 	return nil
+	{{- else if $method.ReturnType | isContainerType -}}
+	var result {{qualifiedTypeName "exports" $method.ReturnType}}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 	{{- else -}}
-	result := new(exports.{{$method.ReturnType | capitalize | symbolize}})
+	result := new({{qualifiedTypeName "exports" $method.ReturnType}})
 	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
 		return nil, err
 	}
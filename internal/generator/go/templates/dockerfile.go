@@ -12,11 +12,11 @@ ENV GONOSUMDB="{{.RepositoryURL | domainUserRepos}}"
 ENV CGO_ENABLED=0
 
 WORKDIR /build
-COPY go.mod ./
+COPY go.mod go.sum ./
 RUN go mod download all
 
 COPY . .
-RUN go build -a -installsuffix cgo -o executable ./cmd
+RUN go build -a -installsuffix cgo -o executable ./{{if .SingleModule}}services/{{.Name}}/{{end}}cmd
 
 
 FROM scratch AS runtime
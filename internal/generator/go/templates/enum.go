@@ -0,0 +1,72 @@
+package templates
+
+// Enum is the template for API enumerations in go code.
+const Enum = `package {{.Package}}
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+{{with $name := .Name | exported}}
+// {{$name}} - generated API enumeration
+type {{$name}} string
+
+// Values of {{$name}}.
+const (
+	{{range $.Values -}}
+	{{$name}}{{. | exported}} {{$name}} = "{{.}}"
+	{{end}}
+)
+
+// IsValid tells if the value is a member of {{$name}}.
+func (e {{$name}}) IsValid() bool {
+	switch e {
+	case {{range $i, $v := $.Values}}{{if $i}}, {{end}}{{$name}}{{$v | exported}}{{end}}:
+		return true
+	}
+	return false
+}
+
+// Parse{{$name}} converts a string to a {{$name}}, failing for unknown values.
+func Parse{{$name}}(s string) ({{$name}}, error) {
+	e := {{$name}}(s)
+	if !e.IsValid() {
+		return "", fmt.Errorf("invalid {{$.Name}} value %q", s)
+	}
+	return e, nil
+}
+
+// MarshalJSON implements json.Marshaler, failing for unknown values.
+// The empty value is the one of unset fields, checked by Validate if required.
+func (e {{$name}}) MarshalJSON() ([]byte, error) {
+	if e != "" && !e.IsValid() {
+		return nil, fmt.Errorf("invalid {{$.Name}} value %q", string(e))
+	}
+	return json.Marshal(string(e))
+}
+
+// UnmarshalJSON implements json.Unmarshaler, failing for unknown values.
+// The empty value is the one of unset fields, checked by Validate if required.
+func (e *{{$name}}) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*e = ""
+		return nil
+	}
+	return e.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, failing for unknown values.
+func (e *{{$name}}) UnmarshalText(b []byte) error {
+	v, err := Parse{{$name}}(string(b))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+{{end}}`
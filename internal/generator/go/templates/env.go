@@ -3,16 +3,33 @@ package templates
 // Env is the template for the environment config in go code.
 const Env = `package config
 
+{{if or (.Environment | hasEnumType) (usesType .Environment "time" "duration" "uuid") -}}
+import (
+	{{if usesType .Environment "time" "duration"}}"time"{{end}}
+
+	{{if usesType .Environment "uuid"}}"github.com/google/uuid"{{end}}
+	"github.com/kelseyhightower/envconfig"
+
+	{{if .Environment | hasEnumType}}"{{.RepositoryURL}}/pkg/exports"{{end}}
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
+)
+{{- else -}}
 import "github.com/kelseyhightower/envconfig"
+{{- end}}
 
 // Env holds all environmental variables for the service app.
 type Env struct {
 	Port string ` + "`" + `default:"{{.Port}}" envconfig:"PORT"` + "`" + `
+	{{- if eq .Auth.Type "api_key"}}
+	AuthAPIKeys map[string]string ` + "`" + `envconfig:"AUTH_API_KEYS" required:"true"` + "`" + `
+	{{- else if eq .Auth.Type "jwt"}}
+	AuthJWTKey string ` + "`" + `envconfig:"AUTH_JWT_KEY" required:"true"` + "`" + `
+	{{- end}}
 	{{range .Environment -}}
-	{{.Name | toLower | capitalize}} {{.Type}} ` + "`" + `default:"{{.Value}}" envconfig:"{{.Name | toUpper}}"` + "`" + `
+	{{.Name | toLower | exported}} {{if or (.Type | isEnumType) (.Type | elementType | isPrimitiveType)}}{{qualifiedTypeName "exports" .Type}}{{else}}{{.Type}}{{end}} ` + "`" + `default:"{{.Value}}" envconfig:"{{.Name | toUpper}}"` + "`" + `
 	{{end}}
 	{{range $d := .Dependencies -}}
-	{{$d | replaceHyphens | toLower | capitalize}}Addr string ` + "`" + `default:"" envconfig:"{{$d | replaceHyphens | toUpper}}_ADDR"` + "`" + `
+	{{$d | toLower | exported}}Addr string ` + "`" + `default:"" envconfig:"{{$d | replaceHyphens | toUpper}}_ADDR"` + "`" + `
 	{{end}}
 }
 
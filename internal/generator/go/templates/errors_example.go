@@ -3,17 +3,15 @@ package templates
 // Errors is the template for the go definition of the errors code.
 const Errors = `package logic
 
-// NotFoundError should be returned when a specific resource
-// requested by the client of the logic does not exist.
-type NotFoundError struct {
-	message string
-}
+import (
+	"{{.RepositoryURL}}/pkg/exports"
+)
 
-func (e *NotFoundError) Error() string {
-	return e.message
-}
-
-// NewNotFoundError creates a custom NotFoundError instance.
-func NewNotFoundError(message string) *NotFoundError {
-	return &NotFoundError{message: message}
-}`
+// The errors declared in the spec, for the implementation to return. They are sent
+// with their HTTP status and payload, and returned as such by the service's client.
+// Errors of your own are sent by writeErrorToHTTPResponse, as internal server errors
+// unless mapped to other statuses there.
+{{range .Errors}}
+// {{.Name | exported}}Error is the error of kind {{.Name}}, sent with status {{.Status}}.
+type {{.Name | exported}}Error = exports.{{.Name | exported}}Error
+{{end}}`
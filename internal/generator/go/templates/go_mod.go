@@ -0,0 +1,23 @@
+package templates
+
+// GoMod is the template for the go.mod file of a generated module.
+// The modules generated along with it are required at a placeholder
+// version and replaced by their directories, as is saas-y when the
+// directory of its source is given.
+const GoMod = `module {{.Name}}
+
+go 1.14
+
+require (
+	github.com/google/uuid v1.1.4
+	github.com/gorilla/mux v1.8.0
+	github.com/kelseyhightower/envconfig v1.4.0
+	github.com/popescu-af/saas-y {{.SaasyVersion}}
+	{{- range .Dependencies}}
+	{{.Name}} {{$.PlaceholderVersion}}
+	{{- end}}
+)
+{{range .Replacements}}
+replace {{.Name}} => {{.Path}}
+{{end -}}
+`
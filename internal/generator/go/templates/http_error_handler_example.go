@@ -7,18 +7,16 @@ const HTTPErrorHandler = `package service
 import (
 	"net/http"
 
-	"{{.RepositoryURL}}/internal/logic"
+	"{{.RepositoryURL}}/pkg/exports"
 )
 
+// writeErrorToHTTPResponse writes the errors returned by the implementation which are
+// not declared in the spec. They are sent as internal server errors, in the same JSON
+// envelope as the declared ones; map errors of your own to other statuses here.
 func writeErrorToHTTPResponse(err error, w http.ResponseWriter) {
 	if err == nil {
 		return
 	}
 
-	switch err.(type) {
-	case *logic.NotFoundError:
-		w.WriteHeader(http.StatusNotFound)
-	}
-
-	w.Write([]byte(err.Error()))
+	writeErrorEnvelope(http.StatusInternalServerError, exports.InternalErrorKind, err.Error(), nil, w)
 }`
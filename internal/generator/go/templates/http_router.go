@@ -8,36 +8,137 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/popescu-af/saas-y/pkg/connection"
 	"github.com/popescu-af/saas-y/pkg/log"
 )
 
-// A PathDefinition groups an HTTP method on a path with its handler function.
+// Middleware wraps the handler of a route with some processing of its requests, e.g. authentication.
+type Middleware func(http.Handler) http.Handler
+
+// A PathDefinition groups an HTTP method on a path with its handler function
+// and the middleware declared for it in the spec, the first one being the outermost.
 type PathDefinition struct {
-	Method  string
-	Path    string
-	Handler http.HandlerFunc
+	Method     string
+	Path       string
+	Handler    http.HandlerFunc
+	Middleware []Middleware
 }
 
 // Paths represents a collection of path definitions.
 type Paths []PathDefinition
 
-// NewRouter creates a new router for the given paths.
-func NewRouter(paths Paths) *mux.Router {
+// NewRouter creates a new router for the given paths. The handlers of all paths are wrapped by
+{{- if .CORS}}
+// the CORS handling, then by the middleware returned by globalMiddleware, then by the given middlewares,
+// then by the middleware of each path, the first one of each being the outermost.
+{{- else}}
+// the middleware returned by globalMiddleware, then by the given middlewares, then by the middleware
+// of each path, the first one of each being the outermost.
+{{- end}}
+func NewRouter(paths Paths, middlewares ...Middleware) *mux.Router {
+	global := append(globalMiddleware(), middlewares...)
+
 	router := mux.NewRouter().StrictSlash(true)
+	{{- if .CORS}}
+	preflightPaths := make(map[string]bool)
+	{{- end}}
 	for _, p := range paths {
+		handler := apiLogger({{if .CORS}}cors({{end}}chain(chain(p.Handler, p.Middleware), global){{if .CORS}}){{end}})
 		if p.Method == "WS" {
 			router.
 				Path(p.Path).
-				Handler(apiLogger(p.Handler))
+				Handler(handler)
 		} else {
 			router.
 				Methods(p.Method).
 				Path(p.Path).
-				Handler(apiLogger(p.Handler))
+				Handler(handler)
+		}
+		{{- if .CORS}}
+
+		// the preflight requests of all methods of a path, answered by cors
+		if !preflightPaths[p.Path] {
+			preflightPaths[p.Path] = true
+			router.
+				Methods(http.MethodOptions).
+				Path(p.Path).
+				Handler(apiLogger(cors(http.HandlerFunc(methodNotAllowed))))
 		}
+		{{- end}}
 	}
 	return router
 }
+{{- with .CORS}}
+
+// checkOrigin tells if a request may come from its origin, as the cors section of the spec says.
+var checkOrigin = connection.CheckOrigins({{range .AllowedOrigins}}"{{.}}", {{end}})
+
+// cors handles the cross-origin requests, answering the preflight ones.
+// The requests from origins not allowed are served without CORS headers,
+// which makes browsers hide the responses from the calling pages.
+func cors(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+		w.Header().Add("Vary", "Origin")
+		if preflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if r.Header.Get("Origin") == "" || !checkOrigin(r) {
+			if preflight {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		{{if .AllowsAnyOrigin -}}
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		{{- else -}}
+		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
+		{{- end}}
+		{{- if .AllowCredentials}}
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		{{- end}}
+		if !preflight {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		{{if .AllowedMethods -}}
+		w.Header().Set("Access-Control-Allow-Methods", "{{join .AllowedMethods ", "}}")
+		{{- else -}}
+		w.Header().Set("Access-Control-Allow-Methods", r.Header.Get("Access-Control-Request-Method"))
+		{{- end}}
+		{{- if .AllowedHeaders}}
+		w.Header().Set("Access-Control-Allow-Headers", "{{join .AllowedHeaders ", "}}")
+		{{- else}}
+		if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		{{- end}}
+		{{- if .MaxAge}}
+		w.Header().Set("Access-Control-Max-Age", "{{.MaxAge}}")
+		{{- end}}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// methodNotAllowed answers the OPTIONS requests which are not preflight ones.
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusMethodNotAllowed)
+}
+{{- end}}
+
+// chain wraps a handler with the given middleware, the first one being the outermost.
+func chain(handler http.Handler, middleware []Middleware) http.Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
 
 func apiLogger(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
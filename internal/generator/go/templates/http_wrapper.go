@@ -4,28 +4,68 @@ package templates
 const HTTPWrapper = `package service
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/popescu-af/saas-y/pkg/auth"
 	"github.com/popescu-af/saas-y/pkg/connection"
 	"github.com/popescu-af/saas-y/pkg/log"
+	"github.com/popescu-af/saas-y/pkg/validation"
 
 	"{{.RepositoryURL}}/pkg/exports"
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
 )
 
 // HTTPWrapper decorates the APIs with from/to HTTP code.
 type HTTPWrapper struct {
 	api exports.API
+	{{- if .Auth.Enabled}}
+	verifier auth.Verifier
+	{{- end}}
+}
+{{if .Auth.Enabled}}
+// NewHTTPWrapper creates an HTTP wrapper for the service API, authenticating
+// the requests with the given verifier.
+func NewHTTPWrapper(api exports.API, verifier auth.Verifier) *HTTPWrapper {
+	return &HTTPWrapper{api: api, verifier: verifier}
 }
 
+// authenticate returns the middleware verifying the credentials of the requests, which
+// passes the authenticated principal on to the implementation in the context of the
+// requests, see auth.FromContext. Requests without credentials are rejected if required,
+// and served anonymously otherwise; requests with invalid credentials are always rejected.
+func (h *HTTPWrapper) authenticate(required bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := h.verifier.Verify(r)
+			if err == auth.ErrNoCredentials && !required {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err != nil {
+				{{- if eq .Auth.Type "jwt"}}
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				{{- end}}
+				writeErrorEnvelope(http.StatusUnauthorized, exports.UnauthorizedErrorKind, err.Error(), nil, w)
+				log.ErrorCtx("authentication failed", log.Context{"error": err})
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
+{{- else}}
 // NewHTTPWrapper creates an HTTP wrapper for the service API.
 func NewHTTPWrapper(api exports.API) *HTTPWrapper {
 	return &HTTPWrapper{api: api}
 }
+{{- end}}
 
 func encodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
@@ -38,25 +78,177 @@ func encodeJSONResponse(i interface{}, status *int, w http.ResponseWriter) error
 	return json.NewEncoder(w).Encode(i)
 }
 
+// writeErrorEnvelope writes an error in the JSON envelope the client decodes it from.
+func writeErrorEnvelope(status int, kind, message string, payload interface{}, w http.ResponseWriter) {
+	e := &exports.Error{Kind: kind, Message: message}
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			log.ErrorCtx("encoding error payload failed", log.Context{"error": err})
+		} else {
+			e.Payload = b
+		}
+	}
+	encodeJSONResponse(e, &status, w)
+}
+
+// writeError writes an error returned by the implementation, with its declared status
+// and payload when it is one of the errors of the spec.
+func writeError(err error, w http.ResponseWriter) {
+	if e, ok := err.(exports.TypedError); ok {
+		writeErrorEnvelope(e.Status(), e.Kind(), e.Error(), e.ErrorPayload(), w)
+		return
+	}
+	writeErrorToHTTPResponse(err, w)
+}
+
+func writeValidationError(err error, w http.ResponseWriter) {
+	var payload interface{}
+	if e, ok := err.(*validation.Error); ok {
+		payload = e
+	}
+	writeErrorEnvelope(http.StatusBadRequest, exports.ValidationErrorKind, err.Error(), payload, w)
+	log.ErrorCtx("validating input failed", log.Context{"error": err})
+}
+
+// Missing optional parameters get the zero value.
 func parseIntParameter(param string) (int64, error) {
+	if param == "" {
+		return 0, nil
+	}
 	return strconv.ParseInt(param, 10, 64)
 }
 
 func parseUintParameter(param string) (uint64, error) {
+	if param == "" {
+		return 0, nil
+	}
 	return strconv.ParseUint(param, 10, 64)
 }
 
 func parseFloatParameter(param string) (float64, error) {
+	if param == "" {
+		return 0, nil
+	}
 	return strconv.ParseFloat(param, 64)
 }
 
+func parseBoolParameter(param string) (bool, error) {
+	if param == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(param)
+}
+
+func parseBytesParameter(param string) ([]byte, error) {
+	return base64.URLEncoding.DecodeString(param)
+}
+
+func parseTimeParameter(param string) (time.Time, error) {
+	if param == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, param)
+}
+
+func parseDurationParameter(param string) (time.Duration, error) {
+	if param == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(param)
+}
+
+func parseIntArrayParameter(params []string) (result []int64, err error) {
+	for _, p := range params {
+		var v int64
+		if v, err = parseIntParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+func parseUintArrayParameter(params []string) (result []uint64, err error) {
+	for _, p := range params {
+		var v uint64
+		if v, err = parseUintParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+func parseFloatArrayParameter(params []string) (result []float64, err error) {
+	for _, p := range params {
+		var v float64
+		if v, err = parseFloatParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+func parseBoolArrayParameter(params []string) (result []bool, err error) {
+	for _, p := range params {
+		var v bool
+		if v, err = parseBoolParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+func parseBytesArrayParameter(params []string) (result [][]byte, err error) {
+	for _, p := range params {
+		var v []byte
+		if v, err = parseBytesParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+func parseTimeArrayParameter(params []string) (result []time.Time, err error) {
+	for _, p := range params {
+		var v time.Time
+		if v, err = parseTimeParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+func parseDurationArrayParameter(params []string) (result []time.Duration, err error) {
+	for _, p := range params {
+		var v time.Duration
+		if v, err = parseDurationParameter(p); err != nil {
+			return
+		}
+		result = append(result, v)
+	}
+	return
+}
+
 // Paths lists the paths that the API serves.
 func (h *HTTPWrapper) Paths() Paths {
 	return Paths{
 		{{range $a := .API}}{{range $mname, $method := $a.Methods}}{
-			strings.ToUpper("{{$method.Type}}"),
-			"{{$a.Path | cleanPath}}",
-			h.{{$mname | capitalize | symbolize}},
+			Method: strings.ToUpper("{{$method.Type}}"),
+			Path: "{{$a.Path | cleanPath}}",
+			Handler: h.{{$mname | exported}},
+			{{- $level := print ($method.AuthLevel $.Auth)}}
+			{{- if or $method.Middleware (ne $level "none")}}
+			Middleware: []Middleware{
+				{{- if eq $level "required"}}h.authenticate(true), {{else if eq $level "optional"}}h.authenticate(false), {{end}}
+				{{- range $method.Middleware}}{{. | unexported}}Middleware, {{end -}}
+			},
+			{{- end}}
 		},
 		{{end}}{{end}}
 	}
@@ -64,16 +256,20 @@ func (h *HTTPWrapper) Paths() Paths {
 
 {{range $a := .API}}{{range $mname, $method := $a.Methods}}
 {{if eq $method.Type "WS"}}
-// {{$mname | capitalize | symbolize}} WebSocket wrapper.
-func (h *HTTPWrapper) {{$mname | capitalize | symbolize}}(w http.ResponseWriter, r *http.Request) {
-	listener, err := h.api.New{{$mname | capitalize | symbolize}}ChannelListener()
+// {{$mname | exported}} WebSocket wrapper.
+func (h *HTTPWrapper) {{$mname | exported}}(w http.ResponseWriter, r *http.Request) {
+	listener, err := h.api.New{{$mname | exported}}ChannelListener(r.Context())
 	if err != nil {
-		writeErrorToHTTPResponse(err, w)
-		log.ErrorCtx("creating instance of {{$mname | capitalize | symbolize}}ChannelListener failed", log.Context{"error": err})
+		writeError(err, w)
+		log.ErrorCtx("creating instance of {{$mname | exported}}ChannelListener failed", log.Context{"error": err})
 		return
 	}
 
+	{{if $.CORS -}}
+	conn, err := connection.NewWebSocketServerWithOptions(w, r, connection.WebSocketServerOptions{CheckOrigin: checkOrigin}, listener)
+	{{- else -}}
 	conn, err := connection.NewWebSocketServer(w, r, listener)
+	{{- end}}
 	if err != nil {
 		writeErrorToHTTPResponse(err, w)
 		log.ErrorCtx("creating websocket connection failed", log.Context{"error": err})
@@ -83,13 +279,16 @@ func (h *HTTPWrapper) {{$mname | capitalize | symbolize}}(w http.ResponseWriter,
 	conn.Run()
 }
 {{else}}
-// {{$mname | capitalize | symbolize}} HTTP wrapper.
-func (h *HTTPWrapper) {{$mname | capitalize | symbolize}}(w http.ResponseWriter, r *http.Request) {
+// {{$mname | exported}} HTTP wrapper.
+func (h *HTTPWrapper) {{$mname | exported}}(w http.ResponseWriter, r *http.Request) {
 	{{if $method.InputType}}// Body
-	{{"body" | pushParam}} := &exports.{{$method.InputType | capitalize | symbolize}}{}
+	{{"body" | pushParam}} := &{{qualifiedTypeName "exports" $method.InputType}}{}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		log.ErrorCtx("decoding input failed", log.Context{"error": err})
+		writeValidationError(err, w)
+		return
+	}
+	if err := body.Validate(); err != nil {
+		writeValidationError(err, w)
 		return
 	}
 
@@ -100,12 +299,19 @@ func (h *HTTPWrapper) {{$mname | capitalize | symbolize}}(w http.ResponseWriter,
 				{{with $ptypeidx := inc $pnameidx}}
 					{{with $ptype := index $params $ptypeidx}}
 						{{if eq $ptype "string"}}
-							{{index $params $pnameidx | decapitalize | pushParam}} := pathParams["{{index $params $pnameidx}}"]
+							{{index $params $pnameidx | paramName | pushParam}} := pathParams["{{index $params $pnameidx}}"]
+
+						{{else if $ptype | valueParserName}}
+							{{index $params $pnameidx | paramName | pushParam}}, err := {{valueParserName $ptype}}(pathParams["{{index $params $pnameidx}}"])
+							if err != nil {
+								writeValidationError(validation.Errorf("{{index $params $pnameidx}}", "%v", err), w)
+								return
+							}
 
 						{{else}}
-							{{index $params $pnameidx | decapitalize | pushParam}}, err := parse{{index $params $ptypeidx | capitalize}}Parameter(pathParams["{{index $params $pnameidx}}"])
+							{{index $params $pnameidx | paramName | pushParam}}, err := parse{{index $params $ptypeidx | capitalize}}Parameter(pathParams["{{index $params $pnameidx}}"])
 							if err != nil {
-								w.WriteHeader(http.StatusBadRequest)
+								writeValidationError(validation.Errorf("{{index $params $pnameidx}}", "%v", err), w)
 								return
 							}
 
@@ -117,28 +323,78 @@ func (h *HTTPWrapper) {{$mname | capitalize | symbolize}}(w http.ResponseWriter,
 	{{end}}{{if $method.QueryParams}}// Query params
 	query := r.URL.Query()
 
-	{{range $method.QueryParams}}{{if eq .Type "string"}}{{.Name | decapitalize | pushParam}} := query.Get("{{.Name}}")
+	{{range $method.QueryParams}}{{if .Required}}if _, ok := query["{{.Name}}"]; !ok {
+		writeValidationError(validation.Errorf("{{.Name}}", "is required"), w)
+		return
+	}
+
+	{{end}}{{if eq .Type "string"}}{{.Name | paramName | pushParam}} := query.Get("{{.Name}}")
+
+	{{else if eq .Type "[]string"}}{{.Name | paramName | pushParam}} := query["{{.Name}}"]
+
+	{{else if .Type | valueParserName}}var {{.Name | paramName | pushParam}} {{qualifiedTypeName "exports" .Type}}
+	if v := query.Get("{{.Name}}"); v != "" {
+		e, err := {{valueParserName .Type}}(v)
+		if err != nil {
+			writeValidationError(validation.Errorf("{{.Name}}", "%v", err), w)
+			return
+		}
+		{{.Name | paramName}} = e
+	}
+
+	{{else if .Type | elementType | valueParserName}}var {{.Name | paramName | pushParam}} {{qualifiedTypeName "exports" .Type}}
+	for _, v := range query["{{.Name}}"] {
+		e, err := {{valueParserName (.Type | elementType)}}(v)
+		if err != nil {
+			writeValidationError(validation.Errorf("{{.Name}}", "%v", err), w)
+			return
+		}
+		{{.Name | paramName}} = append({{.Name | paramName}}, e)
+	}
+
+	{{else if .Type | isArrayType}}{{.Name | paramName | pushParam}}, err := parse{{.Type | elementType | capitalize}}ArrayParameter(query["{{.Name}}"])
+	if err != nil {
+		writeValidationError(validation.Errorf("{{.Name}}", "%v", err), w)
+		return
+	}
 
-	{{else}}{{.Name | decapitalize | pushParam}}, err := parse{{.Type | capitalize}}Parameter(query.Get("{{.Name}}"))
+	{{else}}{{.Name | paramName | pushParam}}, err := parse{{.Type | capitalize}}Parameter(query.Get("{{.Name}}"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeValidationError(validation.Errorf("{{.Name}}", "%v", err), w)
 		return
 	}
 
 	{{end}}{{end}}{{end}}{{if $method.HeaderParams}}// Header params
-	{{range $method.HeaderParams}}{{if eq .Type "string"}}{{.Name | decapitalize | pushParam}} := r.Header.Get("{{.Name}}")
+	{{range $method.HeaderParams}}{{if .Required}}if r.Header.Get("{{.Name}}") == "" {
+		writeValidationError(validation.Errorf("{{.Name}}", "is required"), w)
+		return
+	}
 
-	{{else}}{{.Name | decapitalize | pushParam}}, err := parse{{.Type | capitalize}}Parameter(r.Header.Get("{{.Name}}"))
+	{{end}}{{if eq .Type "string"}}{{.Name | paramName | pushParam}} := r.Header.Get("{{.Name}}")
+
+	{{else if .Type | valueParserName}}var {{.Name | paramName | pushParam}} {{qualifiedTypeName "exports" .Type}}
+	if v := r.Header.Get("{{.Name}}"); v != "" {
+		e, err := {{valueParserName .Type}}(v)
+		if err != nil {
+			writeValidationError(validation.Errorf("{{.Name}}", "%v", err), w)
+			return
+		}
+		{{.Name | paramName}} = e
+	}
+
+	{{else}}{{.Name | paramName | pushParam}}, err := parse{{.Type | capitalize}}Parameter(r.Header.Get("{{.Name}}"))
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		writeValidationError(validation.Errorf("{{.Name}}", "%v", err), w)
 		return
 	}
 
 	{{end}}{{end}}{{end}}
 	// Call implementation
-	{{if ne $method.ReturnType ""}}result,{{end}} err := h.api.{{$mname | capitalize | symbolize}}({{printParamStack}})
+	{{if ne $method.ReturnType ""}}result, err := h.api.{{$mname | exported}}(r.Context(){{with printParamStack}}, {{.}}{{end}})
 	if err != nil {
-		writeErrorToHTTPResponse(err, w)
+	{{- else}}if err := h.api.{{$mname | exported}}(r.Context(){{with printParamStack}}, {{.}}{{end}}); err != nil {
+	{{- end}}
+		writeError(err, w)
 		log.ErrorCtx("call to implementation failed", log.Context{"error": err})
 		return
 	}
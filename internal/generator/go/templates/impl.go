@@ -4,13 +4,16 @@ package templates
 const Impl = `package logic
 
 import (
+	"context"
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/popescu-af/saas-y/pkg/log"
 	"github.com/popescu-af/saas-y/pkg/connection"
 
 	"{{.RepositoryURL}}/pkg/exports"
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
 
 	{{range $d := .DependencyInfos -}}
 	{{$d.Name | cleanName | toLower}} "{{$d.RepositoryURL}}/pkg/exports"
@@ -20,19 +23,19 @@ import (
 // Implementation is the main implementation of the API interface.
 type Implementation struct {
 	{{range $d := .DependencyInfos -}}
-	{{$d.Name | cleanName}} {{$d.Name | cleanName | toLower}}.APIClient
+	{{$d.Name | unexported}} {{$d.Name | cleanName | toLower}}.APIClient
 	{{end}}
 }
 
 // NewImpl creates an instance of the main implementation.
 func NewImpl(
 	{{- range $d := .DependencyInfos -}}
-	{{$d.Name | cleanName}} {{$d.Name | cleanName | toLower}}.APIClient,
+	{{$d.Name | unexported}} {{$d.Name | cleanName | toLower}}.APIClient,
 	{{- end -}}
 ) exports.API {
 	return &Implementation{
 		{{range $d := .DependencyInfos -}}
-		{{$d.Name | cleanName}}: {{$d.Name | cleanName}},
+		{{$d.Name | unexported}}: {{$d.Name | unexported}},
 		{{end}}
 	}
 }
@@ -41,41 +44,41 @@ func NewImpl(
 	// {{$a.Path}}
 	{{range $mname, $method := $a.Methods}}
 	{{if eq $method.Type "WS"}}
-		// New{{$mname | capitalize}}ChannelListener implementation.
-		func (i *Implementation) New{{$mname | capitalize}}ChannelListener() (connection.ChannelListener, error) {
+		// New{{$mname | exported}}ChannelListener implementation.
+		func (i *Implementation) New{{$mname | exported}}ChannelListener(ctx context.Context) (connection.ChannelListener, error) {
 			log.Info("called {{$mname}}")
 			return nil, errors.New("method '{{$mname}}' not implemented")
 		}
 
-		type {{$mname}}ChannelListener struct {
+		type {{$mname | unexported}}ChannelListener struct {
 		}
 
 		// ProcessMessage implements a method of the connection.ChannelListener interface.
-		func (s *{{$mname}}ChannelListener) ProcessMessage(m *connection.Message, write connection.WriteOnChannelFunc) error {
+		func (s *{{$mname | unexported}}ChannelListener) ProcessMessage(m *connection.Message, write connection.WriteOnChannelFunc) error {
 			log.Info("ProcessMessage not implemented")
 			return nil
 		}
 	{{- else -}}
-		// {{$mname | capitalize}} implementation.
-		func (i *Implementation) {{$mname | capitalize}}(
+		// {{$mname | exported}} implementation.
+		func (i *Implementation) {{$mname | exported}}(ctx context.Context,
 			{{- if $method.InputType -}}
-				input *exports.{{$method.InputType | capitalize | symbolize}},
+				input *{{qualifiedTypeName "exports" $method.InputType}},
 			{{- end -}}
 			{{- if $a.Path | pathHasParameters -}}
 				{{- with $params := $a.Path | pathParameters -}}
 					{{- range $pnameidx := $params | indicesParameters -}}
-						{{- index $params $pnameidx}} {{with $ptypeidx := inc $pnameidx}}{{index $params $ptypeidx | typeName}},{{end}}
+						{{- index $params $pnameidx | paramName}} {{with $ptypeidx := inc $pnameidx}}{{index $params $ptypeidx | qualifiedTypeName "exports"}},{{end}}
 					{{- end -}}
 				{{- end -}}
 			{{- end -}}
 			{{- if $method.QueryParams -}}
 				{{- range $method.QueryParams -}}
-					{{- .Name}} {{.Type | typeName}},
+					{{- .Name | paramName}} {{.Type | qualifiedTypeName "exports"}},
 				{{- end -}}
 			{{- end -}}
 			{{- if $method.HeaderParams -}}
 				{{- range $method.HeaderParams -}}
-					{{- .Name}} {{.Type | typeName}},
+					{{- .Name | paramName}} {{.Type | qualifiedTypeName "exports"}},
 				{{- end -}}
 			{{- end -}}
 		)
@@ -84,8 +87,13 @@ func NewImpl(
 			log.Info("called {{$mname}}")
 			return errors.New("method '{{$mname}}' not implemented")
 		}
+		{{- else if $method.ReturnType | isContainerType -}}
+		({{qualifiedTypeName "exports" $method.ReturnType}}, error) {
+			log.Info("called {{$mname}}")
+			return nil, errors.New("method '{{$mname}}' not implemented")
+		}
 		{{- else -}}
-		(*exports.{{$method.ReturnType | capitalize | symbolize}}, error) {
+		(*{{qualifiedTypeName "exports" $method.ReturnType}}, error) {
 			log.Info("called {{$mname}}")
 			return nil, errors.New("method '{{$mname}}' not implemented")
 		}
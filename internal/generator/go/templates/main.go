@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/popescu-af/saas-y/pkg/auth"
 	"github.com/popescu-af/saas-y/pkg/log"
 
 	"{{.RepositoryURL}}/internal/config"
@@ -30,12 +31,23 @@ func main() {
 
 	impl := logic.NewImpl(
 		{{range $d := .DependencyInfos}}
-			{{- with $name := $d.Name | cleanName | capitalize -}}
+			{{- with $name := $d.Name | exported -}}
 				{{$d.Name | cleanName | toLower}}.New{{$name}}Client(env.{{$name}}Addr),
 			{{- end}}
 		{{end}}
 	)
+	{{- if eq .Auth.Type "api_key"}}
+	verifier := auth.NewAPIKeyVerifier("{{.Auth.Header}}", env.AuthAPIKeys)
+	httpWrapper := service.NewHTTPWrapper(impl, verifier)
+	{{- else if eq .Auth.Type "jwt"}}
+	verifier, err := auth.NewJWTVerifier("{{.Auth.Algorithm}}", env.AuthJWTKey)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	httpWrapper := service.NewHTTPWrapper(impl, verifier)
+	{{- else}}
 	httpWrapper := service.NewHTTPWrapper(impl)
+	{{- end}}
 	router := service.NewRouter(httpWrapper.Paths())
 
 	log.Fatal(fmt.Sprintf("error serving - %v", http.ListenAndServe(fmt.Sprintf(":%s", env.Port), router)))
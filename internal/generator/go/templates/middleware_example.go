@@ -0,0 +1,26 @@
+package templates
+
+// Middleware is the template for the go definition of the middleware of the routes.
+const Middleware = `package service
+
+import (
+	"errors"
+	"net/http"
+)
+
+// globalMiddleware returns the middleware wrapping the handlers of all routes, the first one
+// being the outermost, e.g. for tracing or rate limiting. None by default.
+func globalMiddleware() []Middleware {
+	return nil
+}
+
+// The middleware declared in the spec for some of the routes follows, by name. Each one
+// wraps the handlers of its routes and rejects all their requests until implemented.
+{{range middlewareNames .API}}
+// {{. | unexported}}Middleware is the middleware {{.}}.
+func {{. | unexported}}Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeErrorToHTTPResponse(errors.New("middleware '{{.}}' not implemented"), w)
+	})
+}
+{{end}}`
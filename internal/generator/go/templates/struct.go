@@ -1,10 +1,28 @@
 package templates
 
 // Struct is the template for API structures in go code.
-const Struct = `package exports
+const Struct = `package {{.Package}}
 
-// {{.Name | capitalize}} - generated API structure
-type {{.Name | capitalize}} struct {
-	{{range .Fields}}{{.Name | capitalize}} {{.Type | typeName}} ` + "`" + `json:"{{.Name}}"` + "`" + `
+{{if or .SharedRepositoryURL (usesType .Fields "time" "duration" "uuid") -}}
+import (
+	{{if usesType .Fields "time" "duration"}}"time"{{end}}
+
+	{{if usesType .Fields "uuid"}}"github.com/google/uuid"{{end}}
+	"github.com/popescu-af/saas-y/pkg/validation"
+	{{if .SharedRepositoryURL}}"{{.SharedRepositoryURL}}"{{end}}
+)
+{{- else -}}
+import "github.com/popescu-af/saas-y/pkg/validation"
+{{- end}}
+
+// {{.Name | exported}} - generated API structure
+type {{.Name | exported}} struct {
+	{{range .Fields}}{{.Name | exported}} {{fieldTypeName .}} ` + "`" + `json:"{{.Name}}{{if .Optional}},omitempty{{end}}"` + "`" + `
 	{{end}}
+}
+
+// Validate checks the fields of {{.Name | exported}} against the constraints in the spec.
+func (s *{{.Name | exported}}) Validate() error {
+	{{range .Fields}}{{fieldChecks .}}{{end -}}
+	return nil
 }`
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms are the words written in all caps in go identifiers, as golint expects.
+var commonInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true,
+	"EOF": true, "GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true,
+	"IP": true, "JSON": true, "LHS": true, "QPS": true, "RAM": true, "RHS": true,
+	"RPC": true, "SLA": true, "SMTP": true, "SQL": true, "SSH": true, "TCP": true,
+	"TLS": true, "TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true, "XMPP": true,
+	"XSRF": true, "XSS": true,
+}
+
+// goName converts a name of the spec to a go identifier in camel case, exported or not.
+// Words are separated by underscores, dashes and lower to upper case transitions, and the
+// common initialisms are written in all caps, e.g. get_user_ids becomes GetUserIDs or getUserIDs.
+func goName(name string, exported bool) string {
+	var words []string
+	start := 0
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			words = append(words, string(runes[start:i]))
+			start = i + 1
+		case i > start && unicode.IsLower(runes[i-1]) && unicode.IsUpper(r):
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	var b strings.Builder
+	for _, w := range words {
+		if w == "" {
+			continue
+		}
+
+		first := b.Len() == 0
+		switch u := strings.ToUpper(w); {
+		case isInitialism(u) && first && !exported:
+			w = strings.ToLower(w)
+		case commonInitialisms[u]:
+			w = u
+		case isInitialism(u):
+			// plural, e.g. IDs
+			w = u[:len(u)-1] + "s"
+		case first && !exported:
+			w = strings.ToLower(w[:1]) + w[1:]
+		default:
+			w = strings.ToUpper(w[:1]) + w[1:]
+		}
+		b.WriteString(w)
+	}
+	return b.String()
+}
+
+// isInitialism tells if the upper case word is a common initialism or the plural of one.
+func isInitialism(u string) bool {
+	return commonInitialisms[u] || strings.HasSuffix(u, "S") && commonInitialisms[u[:len(u)-1]]
+}
+
+func exportedName(name string) string {
+	return goName(name, true)
+}
+
+func unexportedName(name string) string {
+	return goName(name, false)
+}
+
+// reservedParamNames are the identifiers which the generated methods declare or use, besides
+// the go keywords, and which their params would shadow or clash with if named the same.
+var reservedParamNames = map[string]bool{
+	// receivers, arguments and locals
+	"c": true, "h": true, "i": true, "w": true, "r": true, "b": true, "e": true, "v": true,
+	"ctx": true, "input": true, "body": true, "err": true, "ok": true, "request": true,
+	"requestURL": true, "query": true, "pathParams": true, "response": true, "result": true,
+	"options": true, "conn": true, "listener": true,
+	// packages
+	"auth": true, "bytes": true, "connection": true, "context": true, "errors": true,
+	"exports": true, "fmt": true, "http": true, "io": true, "json": true, "log": true,
+	"mux": true, "strconv": true, "strings": true, "time": true, "url": true, "uuid": true,
+	"validation": true,
+	// builtins
+	"append": true, "error": true, "false": true, "len": true, "make": true, "nil": true,
+	"string": true, "true": true,
+}
+
+// paramName returns the go identifier of a path, query or header param of a method, which
+// is its name in camel case, suffixed with Param if it is reserved, e.g. a query param named
+// url is urlParam, not to shadow the url package.
+func paramName(name string) string {
+	n := unexportedName(name)
+	if reservedParamNames[n] || token.IsKeyword(n) {
+		return n + "Param"
+	}
+	return n
+}
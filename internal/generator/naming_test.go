@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		name       string
+		exported   string
+		unexported string
+	}{
+		{"greet", "Greet", "greet"},
+		{"get_time", "GetTime", "getTime"},
+		{"user_id", "UserID", "userID"},
+		{"id", "ID", "id"},
+		{"ids", "IDs", "ids"},
+		{"api_key", "APIKey", "apiKey"},
+		{"time-svc", "TimeSvc", "timeSvc"},
+		{"http_url_list", "HTTPURLList", "httpURLList"},
+		{"paintColor", "PaintColor", "paintColor"},
+		{"userId", "UserID", "userID"},
+		{"x_request_id", "XRequestID", "xRequestID"},
+		{"utf8", "UTF8", "utf8"},
+		{"v2", "V2", "v2"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.exported, goName(test.name, true), test.name)
+		require.Equal(t, test.unexported, goName(test.name, false), test.name)
+	}
+}
+
+func TestParamName(t *testing.T) {
+	tests := map[string]string{
+		"page_size":   "pageSize",
+		"query":       "queryParam",
+		"url":         "urlParam",
+		"request_url": "requestURLParam",
+		"type":        "typeParam",
+		"user_id":     "userID",
+	}
+
+	for name, expected := range tests {
+		require.Equal(t, expected, paramName(name), name)
+	}
+}
@@ -0,0 +1,377 @@
+package generator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/popescu-af/saas-y/internal/model"
+	"github.com/popescu-af/saas-y/pkg/auth"
+)
+
+// OpenAPI writes the API of a service as an OpenAPI 3 JSON document, describing
+// the requests and responses of the generated HTTP wrapper. Its components hold
+// all structs and enums available to the service, shared ones included.
+func OpenAPI(svc model.Service, outpath string) (err error) {
+	doc := openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: svc.Name, Version: "1.0.0"},
+		Paths:   make(map[string]map[string]*openAPIOperation),
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{
+				errorSchema: {
+					Type: "object",
+					Properties: map[string]*openAPISchema{
+						"error":   {Type: "string", Description: "the kind of the error"},
+						"message": {Type: "string"},
+						"payload": {Description: "the payload of the kind, if any, e.g. a ValidationError for the validation errors"},
+					},
+				},
+				validationErrorSchema: {
+					Type: "object",
+					Properties: map[string]*openAPISchema{
+						"field":  {Type: "string"},
+						"reason": {Type: "string"},
+					},
+				},
+			},
+		},
+	}
+
+	if svc.Auth.Enabled() {
+		doc.Components.SecuritySchemes = map[string]*openAPISecurityScheme{
+			securityScheme: openAPISecuritySchemeOf(svc.Auth),
+		}
+	}
+
+	for _, e := range append(append([]model.Enum{}, svc.SharedEnums...), svc.Enums...) {
+		doc.Components.Schemas[e.Name] = &openAPISchema{Type: "string", Enum: e.Values}
+	}
+	for _, s := range append(append([]model.Struct{}, svc.SharedStructs...), svc.Structs...) {
+		doc.Components.Schemas[s.Name] = openAPIStructSchema(s)
+	}
+
+	for _, a := range svc.API {
+		p := openAPIPath(a.Path)
+		if doc.Paths[p] == nil {
+			doc.Paths[p] = make(map[string]*openAPIOperation)
+		}
+		// a path holds a single operation per HTTP method,
+		// methods clashing on it are described by the first by name
+		names := make([]string, 0, len(a.Methods))
+		for name := range a.Methods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			m := a.Methods[name]
+			if _, ok := doc.Paths[p][openAPIMethod(m.Type)]; !ok {
+				doc.Paths[p][openAPIMethod(m.Type)] = openAPIOperationOf(name, a.Path, m, svc.Auth, svc.Errors)
+			}
+		}
+	}
+
+	b, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return
+	}
+	return ioutil.WriteFile(outpath, append(b, '\n'), 0660)
+}
+
+// errorSchema and validationErrorSchema are the names of the schemas of the error envelope
+// and of the payload of the validation errors returned with status 400, which cannot collide
+// with the snake case names of the spec types.
+const (
+	errorSchema           = "Error"
+	validationErrorSchema = "ValidationError"
+)
+
+// securityScheme is the name of the security scheme of the spec auth.
+const securityScheme = "auth"
+
+// The subset of an OpenAPI 3 document needed to describe a service.
+// Maps are marshalled with sorted keys, which keeps the output deterministic.
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]*openAPISecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type openAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Description string                      `json:"description,omitempty"`
+	Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+	RequestBody *openAPIBody                `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Security    []map[string][]string       `json:"security,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	MinLength            *int                      `json:"minLength,omitempty"`
+	MaxLength            *int                      `json:"maxLength,omitempty"`
+	MinItems             *int                      `json:"minItems,omitempty"`
+	MaxItems             *int                      `json:"maxItems,omitempty"`
+	MinProperties        *int                      `json:"minProperties,omitempty"`
+	MaxProperties        *int                      `json:"maxProperties,omitempty"`
+	Pattern              string                    `json:"pattern,omitempty"`
+}
+
+// openAPIPath removes the types of the path params, e.g. /users/{id:uuid} becomes /users/{id}.
+func openAPIPath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") {
+			segments[i] = s[:strings.Index(s, ":")] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// openAPIMethod returns the HTTP method of a saas-y method type,
+// websocket connections being opened by GET requests.
+func openAPIMethod(t model.APIMethodType) string {
+	if t == model.WS {
+		return "get"
+	}
+	return strings.ToLower(string(t))
+}
+
+// openAPISecuritySchemeOf returns the security scheme of an enabled spec auth.
+func openAPISecuritySchemeOf(a model.Auth) *openAPISecurityScheme {
+	if a.Type == model.AuthTypeJWT {
+		return &openAPISecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	}
+	header := a.Header
+	if header == "" {
+		header = auth.DefaultAPIKeyHeader
+	}
+	return &openAPISecurityScheme{Type: "apiKey", In: "header", Name: header}
+}
+
+func openAPIOperationOf(name, apiPath string, m model.Method, a model.Auth, errors []model.ErrorKind) *openAPIOperation {
+	op := &openAPIOperation{
+		OperationID: name,
+		Responses:   make(map[string]*openAPIResponse),
+	}
+
+	params := pathParameters(apiPath)
+	for i := 0; i < len(params); i += 2 {
+		op.Parameters = append(op.Parameters, openAPIParameter{
+			Name:     params[i],
+			In:       "path",
+			Required: true,
+			Schema:   openAPIParamSchema(params[i+1]),
+		})
+	}
+	for _, p := range m.QueryParams {
+		op.Parameters = append(op.Parameters, openAPIParameter{Name: p.Name, In: "query", Required: p.Required, Schema: openAPIParamSchema(p.Type)})
+	}
+	for _, p := range m.HeaderParams {
+		op.Parameters = append(op.Parameters, openAPIParameter{Name: p.Name, In: "header", Required: p.Required, Schema: openAPIParamSchema(p.Type)})
+	}
+
+	// the kinds of the errors sent with each status, in the order of the spec
+	kinds := make(map[int][]string)
+
+	switch m.AuthLevel(a) {
+	case model.AuthRequired:
+		op.Security = []map[string][]string{{securityScheme: {}}}
+		kinds[401] = append(kinds[401], "unauthorized, if the credentials are missing or invalid")
+	case model.AuthOptional:
+		// the empty requirement makes the credentials optional
+		op.Security = []map[string][]string{{securityScheme: {}}, {}}
+		kinds[401] = append(kinds[401], "unauthorized, if the credentials are invalid")
+	}
+
+	if m.Type == model.WS {
+		op.Description = "Opens a websocket connection."
+		op.Responses["101"] = &openAPIResponse{Description: "Switching Protocols"}
+		addOpenAPIErrorResponses(op, kinds)
+		return op
+	}
+
+	if m.InputType != "" {
+		op.RequestBody = &openAPIBody{Required: true, Content: openAPIJSON(openAPITypeSchema(m.InputType))}
+	}
+
+	ok := &openAPIResponse{Description: "OK"}
+	if m.ReturnType != "" {
+		ok.Content = openAPIJSON(openAPITypeSchema(m.ReturnType))
+	}
+	op.Responses["200"] = ok
+
+	if len(op.Parameters) > 0 || m.InputType != "" {
+		kinds[400] = append(kinds[400], "validation, if a required param is missing or the body breaks the constraints of the spec")
+	}
+	for _, e := range errors {
+		kinds[e.Status] = append(kinds[e.Status], e.Name)
+	}
+	addOpenAPIErrorResponses(op, kinds)
+	op.Responses["default"] = &openAPIResponse{
+		Description: "Unexpected error, of kind internal",
+		Content:     openAPIJSON(&openAPISchema{Ref: openAPIRef(errorSchema)}),
+	}
+	return op
+}
+
+// addOpenAPIErrorResponses adds the responses of the error statuses to an operation,
+// given the kinds of the errors sent with each of them.
+func addOpenAPIErrorResponses(op *openAPIOperation, kinds map[int][]string) {
+	for status, k := range kinds {
+		op.Responses[strconv.Itoa(status)] = &openAPIResponse{
+			Description: http.StatusText(status) + ", with an error of kind " + strings.Join(k, " or "),
+			Content:     openAPIJSON(&openAPISchema{Ref: openAPIRef(errorSchema)}),
+		}
+	}
+}
+
+func openAPIJSON(s *openAPISchema) map[string]*openAPIMediaType {
+	return map[string]*openAPIMediaType{"application/json": {Schema: s}}
+}
+
+func openAPIRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+func openAPIStructSchema(s model.Struct) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: make(map[string]*openAPISchema)}
+	for _, v := range s.Fields {
+		schema.Properties[v.Name] = openAPIFieldSchema(v)
+		if v.Required {
+			schema.Required = append(schema.Required, v.Name)
+		}
+	}
+	return schema
+}
+
+// openAPIFieldSchema returns the schema of a struct field with its constraints. As in the generated
+// Validate methods, lengths apply to containers and bounds and patterns to their elements.
+func openAPIFieldSchema(v model.Variable) *openAPISchema {
+	schema := openAPITypeSchema(v.Type)
+
+	element := schema
+	switch {
+	case model.IsArrayType(v.Type):
+		schema.MinItems, schema.MaxItems = v.MinLength, v.MaxLength
+		element = schema.Items
+	case model.IsMapType(v.Type):
+		schema.MinProperties, schema.MaxProperties = v.MinLength, v.MaxLength
+		element = schema.AdditionalProperties
+	default:
+		schema.MinLength, schema.MaxLength = v.MinLength, v.MaxLength
+	}
+	if v.Min != nil {
+		element.Minimum = v.Min
+	}
+	element.Maximum, element.Pattern = v.Max, v.Pattern
+	return schema
+}
+
+// openAPITypeSchema returns the schema of a type as (un)marshalled in JSON bodies.
+func openAPITypeSchema(t string) *openAPISchema {
+	switch {
+	case model.IsArrayType(t):
+		return &openAPISchema{Type: "array", Items: openAPITypeSchema(model.ElementType(t))}
+	case model.IsMapType(t):
+		return &openAPISchema{Type: "object", AdditionalProperties: openAPITypeSchema(model.MapValueType(t))}
+	case model.IsPointerType(t):
+		return openAPITypeSchema(model.PointeeType(t))
+	}
+
+	switch t {
+	case "int", "uint":
+		s := &openAPISchema{Type: "integer", Format: "int64"}
+		if t == "uint" {
+			zero := 0.0
+			s.Minimum = &zero
+		}
+		return s
+	case "float":
+		return &openAPISchema{Type: "number", Format: "double"}
+	case "string":
+		return &openAPISchema{Type: "string"}
+	case "bool":
+		return &openAPISchema{Type: "boolean"}
+	case "bytes":
+		return &openAPISchema{Type: "string", Format: "byte"}
+	case "time":
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case "duration":
+		return &openAPISchema{Type: "integer", Format: "int64", Description: "nanoseconds"}
+	case "uuid":
+		return &openAPISchema{Type: "string", Format: "uuid"}
+	}
+	return &openAPISchema{Ref: openAPIRef(t)}
+}
+
+// openAPIParamSchema returns the schema of a type as parsed from path, query and header params,
+// which only differ from the JSON ones for bytes, URL-safe base64 encoded, and durations.
+func openAPIParamSchema(t string) *openAPISchema {
+	if model.IsArrayType(t) {
+		return &openAPISchema{Type: "array", Items: openAPIParamSchema(model.ElementType(t))}
+	}
+
+	switch t {
+	case "bytes":
+		return &openAPISchema{Type: "string", Format: "byte", Description: "URL-safe base64"}
+	case "duration":
+		return &openAPISchema{Type: "string", Format: "duration", Description: "Go duration, e.g. 1h30m"}
+	}
+	return openAPITypeSchema(t)
+}
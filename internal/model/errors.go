@@ -0,0 +1,158 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError is a problem found in the spec, at the path of the faulty value,
+// e.g. services[2].api[0].methods.get_user.return_type. The path is relative to
+// the spec file holding the value and the position in the file is given if known.
+type ValidationError struct {
+	File    string
+	Line    int
+	Column  int
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	location := e.File
+	if location != "" && e.Line > 0 {
+		location += fmt.Sprintf(":%d:%d", e.Line, e.Column)
+	}
+	if e.Path != "" {
+		if location != "" {
+			location += ": "
+		}
+		location += e.Path
+	}
+	if location == "" {
+		return e.Message
+	}
+	return location + ": " + e.Message
+}
+
+// ValidationErrors are all the problems found in the spec or one of its entries.
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Position is a line and column in a spec file, both starting at 1.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// add records an error found at the given path. The problems of a nested entry,
+// given as ValidationErrors, are moved under the path unless already located in a file.
+func (errs *ValidationErrors) add(path string, err error) {
+	if err == nil {
+		return
+	}
+
+	nested, ok := err.(ValidationErrors)
+	if !ok {
+		*errs = append(*errs, ValidationError{Path: path, Message: err.Error()})
+		return
+	}
+
+	for _, e := range nested {
+		if e.File == "" {
+			e.Path = joinPath(path, e.Path)
+		}
+		*errs = append(*errs, e)
+	}
+}
+
+func (errs *ValidationErrors) addf(path, format string, args ...interface{}) {
+	errs.add(path, fmt.Errorf(format, args...))
+}
+
+// addAt records the problems of an entry located in the spec files by the parser,
+// at its location if known, or at the given path otherwise.
+func (errs *ValidationErrors) addAt(source, path string, err error) {
+	file, sourcePath := splitSource(source)
+	if file == "" {
+		errs.add(path, err)
+		return
+	}
+	if sourcePath != "" {
+		path = sourcePath
+	}
+
+	var located ValidationErrors
+	located.add(path, err)
+	for i := range located {
+		if located[i].File == "" {
+			located[i].File = file
+		}
+	}
+	*errs = append(*errs, located...)
+}
+
+// locate sets the file of the errors not located yet to the root spec file, and the
+// position of each error to the one of its path or, if missing, of its closest parent.
+func (errs ValidationErrors) locate(root string, positions map[string]map[string]Position) {
+	for i := range errs {
+		if errs[i].File == "" {
+			errs[i].File = root
+		}
+
+		filePositions := positions[errs[i].File]
+		for p := errs[i].Path; ; p = parentPath(p) {
+			if pos, ok := filePositions[p]; ok {
+				errs[i].Line, errs[i].Column = pos.Line, pos.Column
+				break
+			}
+			if p == "" {
+				break
+			}
+		}
+	}
+}
+
+// err returns the errors, or nil if there are none.
+func (errs ValidationErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// splitSource splits the location set by the parser, "file, path" or just "file".
+func splitSource(source string) (file, path string) {
+	if i := strings.LastIndex(source, ", "); i >= 0 {
+		return source[:i], source[i+2:]
+	}
+	return source, ""
+}
+
+func index(list string, i int) string {
+	return fmt.Sprintf("%s[%d]", list, i)
+}
+
+func joinPath(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	if child == "" || strings.HasPrefix(child, "[") {
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// parentPath strips the last member name or index of a path.
+func parentPath(path string) string {
+	i := strings.LastIndexAny(path, ".[")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
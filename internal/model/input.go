@@ -1,20 +1,36 @@
 package model
 
 import (
-	"errors"
+	"encoding/base64"
 	"fmt"
+	"net/url"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Spec is the saas-y specification.
 type Spec struct {
-	RepositoryURL    string            `json:"repository_url"`
-	Domain           string            `json:"domain"`
-	Subdomains       []Subdomain       `json:"subdomains"`
-	Services         []Service         `json:"services"`
-	ExternalServices []ExternalService `json:"external_services"`
+	RepositoryURL    string            `json:"repository_url" yaml:"repository_url"`
+	Domain           string            `json:"domain" yaml:"domain"`
+	SingleModule     bool              `json:"single_module" yaml:"single_module"` // one go module for the whole repository
+	Auth             Auth              `json:"auth" yaml:"auth"`                   // authentication of the requests to all services
+	Subdomains       []Subdomain       `json:"subdomains" yaml:"subdomains"`
+	Services         []Service         `json:"services" yaml:"services"`
+	ExternalServices []ExternalService `json:"external_services" yaml:"external_services"`
+	Structs          []Struct          `json:"structs" yaml:"structs"`
+	Enums            []Enum            `json:"enums" yaml:"enums"`
+	Include          []string          `json:"include" yaml:"include"` // resolved and merged in by the parser
+
+	Source    string                         `json:"-" yaml:"-"` // root spec file, set by the parser
+	Positions map[string]map[string]Position `json:"-" yaml:"-"` // positions of the values by file and path, set by the parser
+}
+
+// SharedRepositoryURL returns the repository URL of the package holding the shared structs and enums.
+func (s *Spec) SharedRepositoryURL() string {
+	return s.RepositoryURL + "/shared"
 }
 
 // GenerateAdditionalInformation generates additional information needed for code/config generation.
@@ -25,6 +41,13 @@ func (s *Spec) GenerateAdditionalInformation() {
 		srvRepo := s.RepositoryURL + "/services/" + s.Services[i].Name
 
 		s.Services[i].RepositoryURL = srvRepo
+		s.Services[i].SingleModule = s.SingleModule
+		s.Services[i].Auth = s.Auth
+		if len(s.Structs) > 0 || len(s.Enums) > 0 {
+			s.Services[i].SharedRepositoryURL = s.SharedRepositoryURL()
+			s.Services[i].SharedStructs = s.Structs
+			s.Services[i].SharedEnums = s.Enums
+		}
 
 		dependencyInfoMap[s.Services[i].Name] = DependencyInfo{
 			Name:          s.Services[i].Name,
@@ -36,20 +59,41 @@ func (s *Spec) GenerateAdditionalInformation() {
 		dependencyInfoMap[s.ExternalServices[i].Name] = DependencyInfo{
 			Name:          s.ExternalServices[i].Name,
 			RepositoryURL: s.ExternalServices[i].RepositoryURL,
+			External:      true,
 		}
 	}
 
+	dependencies := make(map[string][]string)
 	for i := range s.Services {
+		dependencies[s.Services[i].Name] = s.Services[i].Dependencies
 		for _, d := range s.Services[i].Dependencies {
 			s.Services[i].DependencyInfos = append(s.Services[i].DependencyInfos, dependencyInfoMap[d])
 		}
 	}
+
+	// the modules of the services depend on those of the services their dependencies depend on
+	for i := range s.Services {
+		visited := map[string]bool{s.Services[i].Name: true}
+		for _, d := range s.Services[i].Dependencies {
+			visited[d] = true
+		}
+		for queue := append([]string(nil), s.Services[i].Dependencies...); len(queue) > 0; queue = queue[1:] {
+			for _, d := range dependencies[queue[0]] {
+				if !visited[d] {
+					visited[d] = true
+					queue = append(queue, d)
+					s.Services[i].IndirectDependencyInfos = append(s.Services[i].IndirectDependencyInfos, dependencyInfoMap[d])
+				}
+			}
+		}
+	}
 }
 
 // Validate checks a specification.
-func (s *Spec) Validate() (err error) {
+func (s *Spec) Validate() error {
+	var errs ValidationErrors
 	if s.RepositoryURL == "" {
-		return fmt.Errorf("missing 'repository_url' field, please provide one with a valid value")
+		errs.addf("repository_url", "missing 'repository_url' field, please provide one with a valid value")
 	}
 
 	// TODO: repository URL validation
@@ -63,152 +107,431 @@ func (s *Spec) Validate() (err error) {
 		knownServices = append(knownServices, esvc.Name)
 	}
 
-	for _, subd := range s.Subdomains {
-		if err = subd.Validate(knownServices); err != nil {
-			return
+	var sharedTypes []string
+	for _, st := range s.Structs {
+		sharedTypes = append(sharedTypes, st.Name)
+	}
+	for i, e := range s.Enums {
+		errs.addAt(e.Source, index("enums", i), e.Validate())
+		sharedTypes = append(sharedTypes, e.Name)
+	}
+
+	for i, st := range s.Structs {
+		errs.addAt(st.Source, index("structs", i), st.Validate(sharedTypes, s.Enums...))
+	}
+
+	if i, err := validateStructCycles(s.Structs); err != nil {
+		errs.addAt(s.Structs[i].Source, index("structs", i), err)
+	}
+
+	for i, subd := range s.Subdomains {
+		errs.addAt(subd.Source, index("subdomains", i), subd.Validate(knownServices))
+	}
+
+	for i, svc := range s.Services {
+		errs.addAt(svc.Source, index("services", i), svc.Validate(knownServices, sharedTypes, s.Enums...))
+	}
+
+	errs.add("auth", s.Auth.Validate())
+	if !s.Auth.Enabled() {
+		for i, svc := range s.Services {
+			errs.addAt(svc.Source, index("services", i), svc.validateNoAuth())
 		}
 	}
 
-	for _, svc := range s.Services {
-		if err = svc.Validate(knownServices); err != nil {
-			return
+	for i, esvc := range s.ExternalServices {
+		errs.addAt(esvc.Source, index("external_services", i), esvc.Validate(knownServices))
+	}
+
+	errs.locate(s.Source, s.Positions)
+	return errs.err()
+}
+
+// Auth tells how the requests to the services are authenticated.
+type Auth struct {
+	Type      AuthType `json:"type" yaml:"type"`
+	Header    string   `json:"header" yaml:"header"`       // header holding the API keys, X-API-Key by default
+	Algorithm string   `json:"algorithm" yaml:"algorithm"` // algorithm of the JWTs
+}
+
+// AuthType is the type of the credentials of the requests.
+type AuthType string
+
+// The types of credentials.
+const (
+	AuthTypeNone   AuthType = "none"
+	AuthTypeAPIKey AuthType = "api_key" // API keys, in a header
+	AuthTypeJWT    AuthType = "jwt"     // JWTs, as HTTP bearer tokens
+)
+
+// jwtAlgorithms are the supported JWT algorithms, signing with HMAC or RSA keys.
+var jwtAlgorithms = []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512"}
+
+var compiledHeaderRegex *regexp.Regexp
+
+// Enabled tells if the requests are authenticated.
+func (a Auth) Enabled() bool {
+	return a.Type == AuthTypeAPIKey || a.Type == AuthTypeJWT
+}
+
+// Validate checks if the auth section is well defined.
+func (a *Auth) Validate() error {
+	var errs ValidationErrors
+	switch a.Type {
+	case "", AuthTypeNone, AuthTypeAPIKey, AuthTypeJWT:
+	default:
+		errs.addf("type", "invalid auth type %s, expected api_key, jwt or none", a.Type)
+	}
+
+	if a.Header != "" {
+		if a.Type != AuthTypeAPIKey {
+			errs.addf("header", "header is only allowed for the api_key auth type")
+		} else if _, err := validateWithRegex(a.Header, "header", &compiledHeaderRegex, `[A-Za-z0-9-]+`); err != nil {
+			errs.add("header", err)
 		}
 	}
 
-	for _, esvc := range s.ExternalServices {
-		if err = esvc.Validate(knownServices); err != nil {
-			return
+	if a.Type == AuthTypeJWT {
+		valid := false
+		for _, alg := range jwtAlgorithms {
+			valid = valid || a.Algorithm == alg
+		}
+		if !valid {
+			errs.addf("algorithm", "invalid JWT algorithm %s, expected one of %s", a.Algorithm, strings.Join(jwtAlgorithms, ", "))
 		}
+	} else if a.Algorithm != "" {
+		errs.addf("algorithm", "algorithm is only allowed for the jwt auth type")
 	}
-	return
+	return errs.err()
 }
 
 // Subdomain is a subdomain entry in the specification.
 type Subdomain struct {
-	Name  string `json:"name"`
-	Paths []Path `json:"paths"`
+	Name   string `json:"name" yaml:"name"`
+	Paths  []Path `json:"paths" yaml:"paths"`
+	Source string `json:"-" yaml:"-"` // location in the spec files, set by the parser
 }
 
 var compiledSubdomainNameRegex *regexp.Regexp
 
 // Validate checks a subdomain.
-func (s *Subdomain) Validate(knownServices []string) (err error) {
-	errPrefix := "failed to validate subdomain " + s.Name + ": "
-
-	_, err = validateWithRegex(
+func (s *Subdomain) Validate(knownServices []string) error {
+	var errs ValidationErrors
+	_, err := validateWithRegex(
 		s.Name,
 		"subdomain name",
 		&compiledSubdomainNameRegex,
 		`([a-z0-9]([a-z0-9-]*[a-z0-9])?)`,
 	)
-	if err != nil {
-		return errors.New(errPrefix + err.Error())
-	}
+	errs.add("name", err)
 
-	for _, p := range s.Paths {
-		if err = p.Validate(s, knownServices); err != nil {
-			return errors.New(errPrefix + err.Error())
-		}
+	for i, p := range s.Paths {
+		errs.addAt(p.Source, index("paths", i), p.Validate(s, knownServices))
 	}
-	return
+	return errs.err()
 }
 
 // Path represents a URL path.
 type Path struct {
-	Value    string `json:"value"`
-	Endpoint string `json:"endpoint"`
+	Value    string `json:"value" yaml:"value"`
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	Source   string `json:"-" yaml:"-"` // location in the spec files, set by the parser
 }
 
 // Validate checks if the path has a proper value and
 // ends up at a known service, which is defined in the spec
 // as a new service or as an external service.
-func (p *Path) Validate(parent *Subdomain, knownServices []string) (err error) {
-	_, err = ValidatePathValue(p.Value)
-	if err != nil {
-		return
+func (p *Path) Validate(parent *Subdomain, knownServices []string) error {
+	var errs ValidationErrors
+	if _, err := ValidatePathValue(p.Value); err != nil {
+		errs.add("value", err)
 	}
 
+	known := false
 	for _, s := range knownServices {
 		if p.Endpoint == s {
-			return
+			known = true
+			break
 		}
 	}
-
-	err = fmt.Errorf("cannot validate path %s from subdomain %s, unknown endpoint %s", parent.Name, p.Value, p.Endpoint)
-	return
+	if !known {
+		errs.addf("endpoint", "path %s of subdomain %s routes to unknown endpoint %s", p.Value, parent.Name, p.Endpoint)
+	}
+	return errs.err()
 }
 
 var compiledPathRegex *regexp.Regexp
 
 // ValidatePathValue validates a HTTP path.
 func ValidatePathValue(pathValue string) (int, error) {
+	return ValidatePathValueWithEnums(pathValue)
+}
+
+// ValidatePathValueWithEnums validates a HTTP path whose parameters
+// may also be of one of the given enum types.
+func ValidatePathValueWithEnums(pathValue string, enums ...Enum) (int, error) {
+	if len(enums) == 0 {
+		return validateWithRegex(
+			pathValue,
+			"path",
+			&compiledPathRegex,
+			`(/([A-Za-z0-9\-\._]+|\{[A-Za-z0-9_]+:(u?int|float|string|bool|time|duration|uuid)\}))*/?`,
+		)
+	}
+
+	types := []string{"u?int", "float", "string", "bool", "time", "duration", "uuid"}
+	for _, e := range enums {
+		types = append(types, regexp.QuoteMeta(e.Name))
+	}
+
+	var compiledPathWithEnumsRegex *regexp.Regexp
 	return validateWithRegex(
 		pathValue,
 		"path",
-		&compiledPathRegex,
-		`(/([A-Za-z0-9\-\._]+|\{[A-Za-z0-9_]+:(u?int|float|string)\}))*/?`,
+		&compiledPathWithEnumsRegex,
+		`(/([A-Za-z0-9\-\._]+|\{[A-Za-z0-9_]+:(`+strings.Join(types, "|")+`)\}))*/?`,
 	)
 }
 
 // Service represents a saas-y defined service.
 type Service struct {
-	ServiceCommon
-	API             []API            `json:"api"`
-	Structs         []Struct         `json:"structs"`
-	DependencyInfos []DependencyInfo // deduced from the service's dependency list and the existing services' spec
+	ServiceCommon           `yaml:",inline"`
+	API                     []API            `json:"api" yaml:"api"`
+	Structs                 []Struct         `json:"structs" yaml:"structs"`
+	Enums                   []Enum           `json:"enums" yaml:"enums"`
+	Errors                  []ErrorKind      `json:"errors" yaml:"errors"`
+	CORS                    *CORS            `json:"cors" yaml:"cors"`
+	Auth                    Auth             `json:"-" yaml:"-"` // deduced from the spec
+	DependencyInfos         []DependencyInfo `json:"-" yaml:"-"` // deduced from the service's dependency list and the existing services' spec
+	IndirectDependencyInfos []DependencyInfo `json:"-" yaml:"-"` // deduced from the dependency lists of the service's dependencies, transitively
+	SharedRepositoryURL     string           `json:"-" yaml:"-"` // deduced from the spec, empty if there are no shared structs or enums
+	SharedStructs           []Struct         `json:"-" yaml:"-"` // deduced from the spec
+	SharedEnums             []Enum           `json:"-" yaml:"-"` // deduced from the spec
+	SingleModule            bool             `json:"-" yaml:"-"` // deduced from the spec
 }
 
 // DependencyInfo holds information about a dependency that is useful when generating code for a particular service.
 type DependencyInfo struct {
 	Name          string
 	RepositoryURL string
+	External      bool // external services are not generated
 }
 
 // Validate checks if the service is well defined.
-func (s *Service) Validate(knownServices []string) (err error) {
-	errPrefix := "failed to validate service " + s.Name + ": "
+// The shared types are the structs and enums shared by all services of the spec,
+// the latter being also given in full as shared enums.
+func (s *Service) Validate(knownServices, sharedTypes []string, sharedEnums ...Enum) error {
+	var errs ValidationErrors
+	for i, e := range s.Enums {
+		errs.add(index("enums", i), e.Validate())
+	}
+
+	enums := append(append([]Enum{}, sharedEnums...), s.Enums...)
+
+	errs.add("", s.ServiceCommon.Validate(knownServices, enums...))
+
+	knownTypes := append([]string{}, sharedTypes...)
+	defineType := func(name, path string) {
+		// the envelope of the errors of the service
+		if name == "error" {
+			errs.addf(path, "type name %s is reserved", name)
+			return
+		}
+		for _, t := range knownTypes {
+			if name == t {
+				errs.addf(path, "type %s is defined more than once", name)
+				return
+			}
+		}
+		knownTypes = append(knownTypes, name)
+	}
+	for i, st := range s.Structs {
+		defineType(st.Name, index("structs", i)+".name")
+	}
+	for i, e := range s.Enums {
+		defineType(e.Name, index("enums", i)+".name")
+	}
+
+	for i, st := range s.Structs {
+		errs.add(index("structs", i), st.Validate(knownTypes, enums...))
+	}
 
-	if err = s.ServiceCommon.Validate(knownServices); err != nil {
-		return errors.New(errPrefix + err.Error())
+	if i, err := validateStructCycles(s.Structs); err != nil {
+		errs.add(index("structs", i), err)
 	}
 
-	var knownTypes []string
-	for _, s := range s.Structs {
-		if err = s.Validate(); err != nil {
-			return errors.New(errPrefix + err.Error())
+	for i, a := range s.API {
+		errs.add(index("api", i), a.Validate(knownTypes, enums...))
+	}
+
+	seenErrors := make(map[string]bool)
+	for i, e := range s.Errors {
+		errs.add(index("errors", i), e.Validate(knownTypes, enums...))
+		for _, t := range knownTypes {
+			if t == e.Name+"_error" {
+				errs.addf(index("errors", i)+".name", "error %s clashes with type %s", e.Name, t)
+			}
+		}
+		if seenErrors[e.Name] {
+			errs.addf(index("errors", i)+".name", "error %s is defined more than once", e.Name)
 		}
-		knownTypes = append(knownTypes, s.Name)
+		seenErrors[e.Name] = true
 	}
 
-	for _, a := range s.API {
-		if err = a.Validate(knownTypes); err != nil {
-			return errors.New(errPrefix + err.Error())
+	if s.CORS != nil {
+		errs.add("cors", s.CORS.Validate())
+	}
+	return errs.err()
+}
+
+// CORS tells which browser origins may call the API of a service, and how.
+type CORS struct {
+	AllowedOrigins   []string `json:"allowed_origins" yaml:"allowed_origins"` // e.g. https://example.com, or * for any
+	AllowedMethods   []string `json:"allowed_methods" yaml:"allowed_methods"` // the requested ones if empty
+	AllowedHeaders   []string `json:"allowed_headers" yaml:"allowed_headers"` // the requested ones if empty
+	AllowCredentials bool     `json:"allow_credentials" yaml:"allow_credentials"`
+	MaxAge           int      `json:"max_age" yaml:"max_age"` // seconds the preflight responses may be cached for
+}
+
+// corsMethods are the methods which may be allowed, those of the API methods.
+var corsMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// AllowsAnyOrigin tells if the API may be called from any origin.
+func (c CORS) AllowsAnyOrigin() bool {
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			return true
 		}
 	}
-	return
+	return false
+}
+
+// Validate checks if the CORS section is well defined.
+func (c *CORS) Validate() error {
+	var errs ValidationErrors
+	if len(c.AllowedOrigins) == 0 {
+		errs.addf("allowed_origins", "at least one allowed origin is required")
+	}
+	for i, o := range c.AllowedOrigins {
+		path := index("allowed_origins", i)
+		if o == "*" {
+			if c.AllowCredentials {
+				errs.addf(path, "origin * is not allowed with allow_credentials, list the origins instead")
+			}
+			continue
+		}
+		// browsers send the origins as scheme://host[:port]
+		u, err := url.Parse(o)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			(&url.URL{Scheme: u.Scheme, Host: u.Host}).String() != o {
+			errs.addf(path, "invalid origin %s, expected a scheme and a host, e.g. https://example.com, or *", o)
+		}
+	}
+
+	for i, m := range c.AllowedMethods {
+		valid := false
+		for _, cm := range corsMethods {
+			valid = valid || m == cm
+		}
+		if !valid {
+			errs.addf(index("allowed_methods", i), "invalid method %s, expected one of %s", m, strings.Join(corsMethods, ", "))
+		}
+	}
+
+	for i, h := range c.AllowedHeaders {
+		if _, err := validateWithRegex(h, "header", &compiledHeaderRegex, `[A-Za-z0-9-]+`); err != nil {
+			errs.add(index("allowed_headers", i), err)
+		}
+	}
+
+	if c.MaxAge < 0 {
+		errs.addf("max_age", "max_age must not be negative")
+	}
+	return errs.err()
+}
+
+// ErrorKind is a kind of error returned by the API of a service, sent with
+// its HTTP status and, optionally, a payload struct describing it further.
+type ErrorKind struct {
+	Name    string `json:"name" yaml:"name"`
+	Status  int    `json:"status" yaml:"status"`
+	Payload string `json:"payload" yaml:"payload"`
+}
+
+// reservedErrorKinds are the kinds of the errors sent by the generated code itself.
+var reservedErrorKinds = []string{"validation", "unauthorized", "internal"}
+
+// Validate checks if the error kind is well defined.
+// Its payload may be any of the known types which is a struct.
+func (e *ErrorKind) Validate(knownTypes []string, enums ...Enum) error {
+	var errs ValidationErrors
+	errs.add("name", ValidateName(e.Name, "error name"))
+	for _, r := range reservedErrorKinds {
+		if e.Name == r {
+			errs.addf("name", "error name %s is reserved", e.Name)
+		}
+	}
+
+	if e.Status < 400 || e.Status > 599 {
+		errs.addf("status", "invalid status %d, errors have 4xx or 5xx statuses", e.Status)
+	}
+
+	if e.Payload != "" {
+		known := false
+		for _, t := range knownTypes {
+			known = known || t == e.Payload
+		}
+		if !known || findEnum(e.Payload, enums) != nil {
+			errs.addf("payload", "unknown struct %s", e.Payload)
+		}
+	}
+	return errs.err()
 }
 
 // API represents a saas-y defined API.
 type API struct {
-	Path    string            `json:"path"`
-	Methods map[string]Method `json:"methods"`
+	Path    string            `json:"path" yaml:"path"`
+	Methods map[string]Method `json:"methods" yaml:"methods"`
 }
 
 // Validate checks if the API is well defined.
-func (a *API) Validate(knownTypes []string) (err error) {
-	errPrefix := "failed to validate API " + a.Path + ": "
+// Path, query and header params may be of any of the given enum types.
+func (a *API) Validate(knownTypes []string, enums ...Enum) error {
+	var errs ValidationErrors
+	if _, err := ValidatePathValueWithEnums(a.Path, enums...); err != nil {
+		errs.add("path", err)
+	}
 
-	_, err = ValidatePathValue(a.Path)
-	if err != nil {
-		return errors.New(errPrefix + err.Error())
+	names := make([]string, 0, len(a.Methods))
+	for name := range a.Methods {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	for _, m := range a.Methods {
-		if err = m.Validate(knownTypes); err != nil {
-			return errors.New(errPrefix + err.Error())
+	for _, name := range names {
+		m := a.Methods[name]
+		errs.add("methods."+name, m.Validate(knownTypes, enums...))
+	}
+	return errs.err()
+}
+
+// validateNoAuth checks that no method requires authentication, for specs without an auth section.
+func (s *Service) validateNoAuth() error {
+	var errs ValidationErrors
+	for i, a := range s.API {
+		names := make([]string, 0, len(a.Methods))
+		for name := range a.Methods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if level := a.Methods[name].Auth; level == AuthRequired || level == AuthOptional {
+				errs.addf(index("api", i)+".methods."+name+".auth", "auth %s is not allowed without an auth section in the spec", level)
+			}
 		}
 	}
-	return
+	return errs.err()
 }
 
 // APIMethodType is the type for saas-y API methods.
@@ -229,15 +552,40 @@ const (
 
 // Method represents a saas-y API method.
 type Method struct {
-	Type         APIMethodType `json:"type"`
-	HeaderParams []Variable    `json:"header_params"`
-	QueryParams  []Variable    `json:"query_params"`
-	InputType    string        `json:"input_type"`
-	ReturnType   string        `json:"return_type"`
+	Type         APIMethodType `json:"type" yaml:"type"`
+	HeaderParams []Variable    `json:"header_params" yaml:"header_params"`
+	QueryParams  []Variable    `json:"query_params" yaml:"query_params"`
+	InputType    string        `json:"input_type" yaml:"input_type"`
+	ReturnType   string        `json:"return_type" yaml:"return_type"`
+	Middleware   []string      `json:"middleware" yaml:"middleware"`
+	Auth         AuthLevel     `json:"auth" yaml:"auth"` // required by default if the spec has an auth section
+}
+
+// AuthLevel tells if the requests to a method must be authenticated.
+type AuthLevel string
+
+// The auth levels of the methods.
+const (
+	AuthRequired AuthLevel = "required" // requests without valid credentials are rejected
+	AuthOptional AuthLevel = "optional" // requests without credentials are served too, anonymously
+	AuthNone     AuthLevel = "none"     // credentials are not checked
+)
+
+// AuthLevel returns the auth level of the method, given the auth of the spec.
+func (m Method) AuthLevel(a Auth) AuthLevel {
+	switch {
+	case !a.Enabled():
+		return AuthNone
+	case m.Auth == "":
+		return AuthRequired
+	}
+	return m.Auth
 }
 
 // Validate checks if the method is well defined.
-func (m *Method) Validate(knownTypes []string) (err error) {
+// Query and header params may be of any of the given enum types.
+func (m *Method) Validate(knownTypes []string, enums ...Enum) error {
+	var errs ValidationErrors
 	typeOK := false
 	for _, t := range []APIMethodType{GET, POST, PATCH, DELETE, WS} {
 		if m.Type == t {
@@ -247,71 +595,117 @@ func (m *Method) Validate(knownTypes []string) (err error) {
 	}
 
 	if !typeOK {
-		err = fmt.Errorf("invalid method type %s", m.Type)
-		return
+		errs.addf("type", "invalid method type %s", m.Type)
 	}
 
-	for _, p := range m.HeaderParams {
-		if err = p.Validate(); err != nil {
-			return
+	for i, p := range m.HeaderParams {
+		path := index("header_params", i)
+		if IsArrayType(p.Type) {
+			errs.addf(path+".type", "array type %s is not allowed for header param %s", p.Type, p.Name)
 		}
+		errs.add(path, validateParamType(p, enums))
+		errs.add(path, p.Validate(enums...))
 	}
-	for _, p := range m.QueryParams {
-		if err = p.Validate(); err != nil {
-			return
-		}
+	for i, p := range m.QueryParams {
+		path := index("query_params", i)
+		errs.add(path, validateParamType(p, enums))
+		errs.add(path, p.Validate(enums...))
 	}
 
-	if m.Type == WS && (m.InputType != "" || m.ReturnType != "") {
-		err = fmt.Errorf("neither body nor return type are allowed for method type %s", m.Type)
-		return
+	switch m.Auth {
+	case "", AuthRequired, AuthOptional, AuthNone:
+	default:
+		errs.addf("auth", "invalid auth %s, expected required, optional or none", m.Auth)
 	}
 
-	for _, t := range []APIMethodType{GET, DELETE} {
-		if m.Type == t && m.InputType != "" {
-			err = fmt.Errorf("body is not allowed for method type %s", m.Type)
-			return
+	for i, name := range m.Middleware {
+		path := index("middleware", i)
+		errs.add(path, ValidateName(name, "middleware name"))
+		for _, previous := range m.Middleware[:i] {
+			if name == previous {
+				errs.addf(path, "middleware %s is listed more than once", name)
+				break
+			}
 		}
 	}
 
-	foundInputType := false
-	if m.InputType == "" {
-		foundInputType = true
+	knownType := func(t string) bool {
+		for _, k := range knownTypes {
+			if t == k {
+				return true
+			}
+		}
+		return false
 	}
 
-	foundReturnType := false
-	if m.ReturnType == "" {
-		foundReturnType = true
+	if m.InputType != "" {
+		switch {
+		case m.Type == GET || m.Type == DELETE || m.Type == WS:
+			errs.addf("input_type", "body is not allowed for method type %s", m.Type)
+		case IsContainerType(m.InputType):
+			errs.addf("input_type", "container type %s is not allowed as body", m.InputType)
+		case !knownType(m.InputType):
+			errs.addf("input_type", "unknown type %s", m.InputType)
+		}
 	}
 
-	for _, t := range knownTypes {
-		if m.InputType == t {
-			foundInputType = true
-		}
-		if m.ReturnType == t {
-			foundReturnType = true
+	if m.ReturnType != "" {
+		returnType := ContainedType(m.ReturnType)
+		switch {
+		case m.Type == WS:
+			errs.addf("return_type", "return type is not allowed for method type %s", m.Type)
+		case IsMapType(m.ReturnType) && validateMapType(m.ReturnType) != nil:
+			errs.add("return_type", validateMapType(m.ReturnType))
+		case IsContainerType(m.ReturnType) && IsPrimitiveType(returnType):
+		case !knownType(returnType):
+			errs.addf("return_type", "unknown type %s", m.ReturnType)
 		}
 	}
+	return errs.err()
+}
 
-	if !foundInputType {
-		err = fmt.Errorf("unknown type %s", m.InputType)
-		return
+// validateParamType checks that a param is of a primitive or enum type, or an array of these.
+func validateParamType(p Variable, enums []Enum) error {
+	if p.HasConstraints() {
+		return fmt.Errorf("constraints are only allowed for struct fields, not for param %s", p.Name)
 	}
 
-	if !foundReturnType {
-		err = fmt.Errorf("unknown type %s", m.ReturnType)
-		return
+	t := ElementType(p.Type)
+	if IsPrimitiveType(t) || findEnum(t, enums) != nil {
+		return nil
 	}
-
-	return
+	return fmt.Errorf("invalid type %s for param %s", p.Type, p.Name)
 }
 
 // Variable represents an environment / struct variable
 // or a header / query param.
 type Variable struct {
-	Name  string `json:"name"`
-	Type  string `json:"type"`
-	Value string `json:"value"`
+	Name  string `json:"name" yaml:"name"`
+	Type  string `json:"type" yaml:"type"`
+	Value string `json:"value" yaml:"value"`
+
+	// Presence and constraints, checked by the generated code.
+	Required  bool     `json:"required" yaml:"required"`
+	Optional  bool     `json:"optional" yaml:"optional"`
+	Min       *float64 `json:"min" yaml:"min"`
+	Max       *float64 `json:"max" yaml:"max"`
+	MinLength *int     `json:"min_length" yaml:"min_length"`
+	MaxLength *int     `json:"max_length" yaml:"max_length"`
+	Pattern   string   `json:"pattern" yaml:"pattern"`
+}
+
+// HasConstraints tells if any value constraint is declared for the variable.
+func (v *Variable) HasConstraints() bool {
+	return v.Min != nil || v.Max != nil || v.MinLength != nil || v.MaxLength != nil || v.Pattern != ""
+}
+
+// FieldType returns the type of the variable when used as a struct field.
+// Optional fields are pointers, except for arrays, maps and bytes which are nil when missing.
+func (v *Variable) FieldType() string {
+	if v.Optional && !IsContainerType(v.Type) && v.Type != "bytes" && !IsPointerType(v.Type) {
+		return "*" + v.Type
+	}
+	return v.Type
 }
 
 var compiledNameRegex *regexp.Regexp
@@ -328,115 +722,475 @@ func ValidateName(name, typeName string) (err error) {
 }
 
 // Validate checks if the variable is well defined.
-func (v *Variable) Validate() (err error) {
+// Its value may be a member of one of the given enums.
+func (v *Variable) Validate(enums ...Enum) error {
+	var errs ValidationErrors
 	if len(v.Value) > 0 {
-		switch v.Type {
-		case "int":
-			if _, err = strconv.ParseInt(v.Value, 10, 64); err != nil {
-				return fmt.Errorf("invalid int value %s", v.Value)
-			}
-		case "uint":
-			if _, err = strconv.ParseUint(v.Value, 10, 64); err != nil {
-				return fmt.Errorf("invalid int value %s", v.Value)
+		if IsArrayType(v.Type) {
+			// array values are given as comma-separated lists
+			for _, e := range strings.Split(v.Value, ",") {
+				if err := validateValue(ElementType(v.Type), e, enums); err != nil {
+					errs.add("value", err)
+					break
+				}
 			}
-		case "float":
-			if _, err = strconv.ParseFloat(v.Value, 64); err != nil {
-				return fmt.Errorf("invalid float value %s", v.Value)
-			}
-		case "string":
-			// any value is good
+		} else {
+			errs.add("value", validateValue(v.Type, v.Value, enums))
+		}
+	}
+
+	errs.add("name", ValidateName(v.Name, "variable name"))
+	errs.add("", v.validateConstraints())
+	return errs.err()
+}
+
+// validateConstraints checks that the declared constraints make sense for the variable type.
+// Numeric bounds and patterns apply to each element of an array or map, lengths to the container itself.
+func (v *Variable) validateConstraints() error {
+	var errs ValidationErrors
+	if v.Required && v.Optional {
+		errs.addf("optional", "variable %s cannot be both required and optional", v.Name)
+	}
+
+	t := ContainedType(v.Type)
+	isNumber := t == "int" || t == "uint" || t == "float"
+	if (v.Min != nil || v.Max != nil) && !isNumber {
+		errs.addf(constraintPath(v.Min != nil, "min", "max"), "min/max are only allowed for numeric types, variable %s is %s", v.Name, v.Type)
+	} else if v.Min != nil && v.Max != nil && *v.Min > *v.Max {
+		errs.addf("min", "min is greater than max for variable %s", v.Name)
+	}
+
+	if (v.MinLength != nil || v.MaxLength != nil) && v.Type != "string" && v.Type != "bytes" && !IsContainerType(v.Type) {
+		errs.addf(constraintPath(v.MinLength != nil, "min_length", "max_length"), "min_length/max_length are only allowed for strings, bytes, arrays and maps, variable %s is %s", v.Name, v.Type)
+	} else if (v.MinLength != nil && *v.MinLength < 0) || (v.MaxLength != nil && *v.MaxLength < 0) {
+		errs.addf(constraintPath(v.MinLength != nil && *v.MinLength < 0, "min_length", "max_length"), "negative length constraint for variable %s", v.Name)
+	} else if v.MinLength != nil && v.MaxLength != nil && *v.MinLength > *v.MaxLength {
+		errs.addf("min_length", "min_length is greater than max_length for variable %s", v.Name)
+	}
+
+	if v.Pattern != "" {
+		if t != "string" {
+			errs.addf("pattern", "pattern is only allowed for strings, variable %s is %s", v.Name, v.Type)
+		} else if _, err := regexp.Compile(v.Pattern); err != nil {
+			errs.addf("pattern", "invalid pattern for variable %s: %v", v.Name, err)
+		}
+	}
+	return errs.err()
+}
+
+// constraintPath returns the path of the first of two constraints if set, of the second otherwise.
+func constraintPath(firstSet bool, first, second string) string {
+	if firstSet {
+		return first
+	}
+	return second
+}
+
+func validateValue(t, value string, enums []Enum) (err error) {
+	if e := findEnum(t, enums); e != nil {
+		if !e.Has(value) {
+			return fmt.Errorf("invalid %s value %s", t, value)
+		}
+		return
+	}
+
+	switch t {
+	case "int":
+		if _, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("invalid int value %s", value)
+		}
+	case "uint":
+		if _, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return fmt.Errorf("invalid int value %s", value)
+		}
+	case "float":
+		if _, err = strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("invalid float value %s", value)
+		}
+	case "string":
+		// any value is good
+	case "bool":
+		if _, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid bool value %s", value)
+		}
+	case "bytes":
+		if _, err = base64.StdEncoding.DecodeString(value); err != nil {
+			return fmt.Errorf("invalid base64 bytes value %s", value)
+		}
+	case "time":
+		if _, err = time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("invalid RFC 3339 time value %s", value)
+		}
+	case "duration":
+		if _, err = time.ParseDuration(value); err != nil {
+			return fmt.Errorf("invalid duration value %s", value)
+		}
+	case "uuid":
+		if _, err = validateWithRegex(value, "uuid", &compiledUUIDRegex, `[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}`); err != nil {
+			return fmt.Errorf("invalid uuid value %s", value)
+		}
+	default:
+		return fmt.Errorf("invalid type %s", t)
+	}
+	return
+}
+
+var compiledUUIDRegex *regexp.Regexp
+
+// IsPrimitiveType tells if the given type is one of the built-in saas-y types.
+func IsPrimitiveType(t string) bool {
+	switch t {
+	case "int", "uint", "float", "string", "bool", "bytes", "time", "duration", "uuid":
+		return true
+	}
+	return false
+}
+
+// IsArrayType tells if the given type is an array type, i.e. of the form []T.
+func IsArrayType(t string) bool {
+	return strings.HasPrefix(t, "[]")
+}
+
+// ElementType returns the type of the elements of an array type.
+// For non-array types, the type itself is returned.
+func ElementType(t string) string {
+	return strings.TrimPrefix(t, "[]")
+}
+
+// IsPointerType tells if the given type is a pointer type, i.e. of the form *T.
+func IsPointerType(t string) bool {
+	return strings.HasPrefix(t, "*")
+}
+
+// PointeeType returns the type pointed to by a pointer type.
+// For non-pointer types, the type itself is returned.
+func PointeeType(t string) string {
+	return strings.TrimPrefix(t, "*")
+}
+
+// IsMapType tells if the given type is a map type, i.e. of the form map<K,V>.
+func IsMapType(t string) bool {
+	return strings.HasPrefix(t, "map<") && strings.HasSuffix(t, ">")
+}
+
+// MapKeyType returns the key type of a map type.
+func MapKeyType(t string) string {
+	k, _ := mapTypes(t)
+	return k
+}
+
+// MapValueType returns the value type of a map type.
+func MapValueType(t string) string {
+	_, v := mapTypes(t)
+	return v
+}
+
+func mapTypes(t string) (key, value string) {
+	inner := strings.TrimSuffix(strings.TrimPrefix(t, "map<"), ">")
+	// keys are primitives, so the first comma separates the key from the value
+	tokens := strings.SplitN(inner, ",", 2)
+	if len(tokens) != 2 {
+		return strings.TrimSpace(inner), ""
+	}
+	return strings.TrimSpace(tokens[0]), strings.TrimSpace(tokens[1])
+}
+
+// validateMapType checks that a map type has a string or int key and a value type.
+func validateMapType(t string) error {
+	key, value := mapTypes(t)
+	if key != "string" && key != "int" {
+		return fmt.Errorf("invalid key type %s in %s, only string and int keys are allowed", key, t)
+	}
+	if value == "" {
+		return fmt.Errorf("missing value type in %s", t)
+	}
+	return nil
+}
+
+// ContainedType returns the type of the values held by an array or a map type.
+// For other types, the type itself is returned.
+func ContainedType(t string) string {
+	if IsMapType(t) {
+		return MapValueType(t)
+	}
+	return ElementType(t)
+}
+
+// IsContainerType tells if the given type is an array or a map type.
+func IsContainerType(t string) bool {
+	return IsArrayType(t) || IsMapType(t)
+}
+
+// BaseType returns the innermost type of a type, stripped of arrays, maps and pointers.
+func BaseType(t string) string {
+	for {
+		switch {
+		case IsContainerType(t):
+			t = ContainedType(t)
+		case IsPointerType(t):
+			t = PointeeType(t)
 		default:
-			return fmt.Errorf("invalid type %s", v.Type)
+			return t
 		}
 	}
+}
+
+// Enum represents a closed set of string values.
+type Enum struct {
+	Name   string   `json:"name" yaml:"name"`
+	Values []string `json:"values" yaml:"values"`
+	Source string   `json:"-" yaml:"-"` // location in the spec files, set by the parser
+}
+
+var compiledEnumValueRegex *regexp.Regexp
+
+// Validate checks if the enum is well defined.
+func (e *Enum) Validate() error {
+	var errs ValidationErrors
+	errs.add("name", ValidateName(e.Name, "enum name"))
+
+	if len(e.Values) == 0 {
+		errs.addf("values", "no values")
+	}
 
-	return ValidateName(v.Name, "variable name")
+	seen := make(map[string]bool)
+	for i, v := range e.Values {
+		_, err := validateWithRegex(
+			v,
+			"enum value",
+			&compiledEnumValueRegex,
+			`[A-Za-z0-9]+([_-][A-Za-z0-9]+)*`,
+		)
+		if err != nil {
+			errs.add(index("values", i), err)
+		} else if seen[v] {
+			errs.addf(index("values", i), "duplicate value %s", v)
+		}
+		seen[v] = true
+	}
+	return errs.err()
+}
+
+// Has tells if the given value is a member of the enum.
+func (e *Enum) Has(value string) bool {
+	for _, v := range e.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func findEnum(name string, enums []Enum) *Enum {
+	for i := range enums {
+		if enums[i].Name == name {
+			return &enums[i]
+		}
+	}
+	return nil
 }
 
 // Struct represents an API struct.
 type Struct struct {
-	Name   string     `json:"name"`
-	Fields []Variable `json:"fields"`
+	Name   string     `json:"name" yaml:"name"`
+	Fields []Variable `json:"fields" yaml:"fields"`
+	Source string     `json:"-" yaml:"-"` // location in the spec files, set by the parser
 }
 
 // Validate checks if the struct is well defined.
-func (s *Struct) Validate() (err error) {
-	errPrefix := "failed to validate struct " + s.Name + ": "
+// Fields may refer to any of the known types, i.e. the structs and enums
+// available to the service, the latter being also given in full as enums.
+func (s *Struct) Validate(knownTypes []string, enums ...Enum) error {
+	var errs ValidationErrors
+	errs.add("name", ValidateName(s.Name, "struct name"))
+
+	for i, v := range s.Fields {
+		path := index("fields", i)
+		errs.add(path, v.Validate(enums...))
+		if err := validateFieldType(v.Type, knownTypes); err != nil {
+			errs.add(path+".type", err)
+		} else if err = validateFieldPresence(v, enums); err != nil {
+			presence := ".required"
+			if v.Optional {
+				presence = ".optional"
+			}
+			errs.add(path+presence, err)
+		}
+	}
+	return errs.err()
+}
+
+// HasDistinctZeroValue tells if the zero value of a type can be told apart from a meaningful value.
+func HasDistinctZeroValue(t string) bool {
+	switch t {
+	case "string", "bytes", "time", "uuid":
+		return true
+	}
+	return IsContainerType(t) || IsPointerType(t)
+}
+
+// validateFieldPresence checks that a field declared as required or optional can be
+// told apart from a missing one, which is not possible for the zero values of numbers
+// and structs held by value.
+func validateFieldPresence(v Variable, enums []Enum) error {
+	if v.Optional && IsPointerType(v.Type) {
+		return fmt.Errorf("pointer type %s is already optional", v.Type)
+	}
+	if v.Required && !HasDistinctZeroValue(v.Type) && findEnum(v.Type, enums) == nil {
+		return fmt.Errorf("required is only allowed for strings, bytes, times, uuids, enums, arrays, maps and pointers, got %s; use optional instead", v.Type)
+	}
+	return nil
+}
 
-	if err = ValidateName(s.Name, "struct name"); err != nil {
-		return errors.New(errPrefix + err.Error())
+// validateFieldType checks that a struct field type is either a primitive,
+// a known type, a pointer to a known type, or an array or map of these.
+func validateFieldType(t string, knownTypes []string) error {
+	if IsArrayType(t) {
+		return validateFieldType(ElementType(t), knownTypes)
 	}
+	if IsMapType(t) {
+		if err := validateMapType(t); err != nil {
+			return err
+		}
+		return validateFieldType(MapValueType(t), knownTypes)
+	}
+
+	isPointer := IsPointerType(t)
+	base := PointeeType(t)
 
-	for _, v := range s.Fields {
-		if err = v.Validate(); err != nil {
-			return errors.New(errPrefix + err.Error())
+	if IsPrimitiveType(base) {
+		if isPointer {
+			return fmt.Errorf("pointers are only allowed to struct and enum types, got %s", t)
 		}
+		return nil
 	}
-	return
+
+	for _, k := range knownTypes {
+		if base == k {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown type %s", t)
+}
+
+// validateStructCycles checks that no struct contains itself by value,
+// either directly or through other structs. Pointers and arrays break cycles.
+// A cycle is reported along with the index of the struct it was found from.
+func validateStructCycles(structs []Struct) (int, error) {
+	valueFields := make(map[string][]string)
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			if !IsPrimitiveType(f.Type) && !IsContainerType(f.Type) && !IsPointerType(f.Type) {
+				valueFields[s.Name] = append(valueFields[s.Name], f.Type)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		chain = append(chain, name)
+		switch state[name] {
+		case visiting:
+			for i := range chain {
+				if chain[i] == name {
+					chain = chain[i:]
+					break
+				}
+			}
+			return fmt.Errorf("struct cycle by value %s, use a pointer to break it", strings.Join(chain, " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, next := range valueFields[name] {
+			if err := visit(next, chain); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for i, s := range structs {
+		if err := visit(s.Name, nil); err != nil {
+			return i, err
+		}
+	}
+	return 0, nil
 }
 
 // ExternalService defines a service that is defined outside of the spec.
 type ExternalService struct {
-	ServiceCommon
-	ImageURL string `json:"image_url"`
+	ServiceCommon `yaml:",inline"`
+	ImageURL      string `json:"image_url" yaml:"image_url"`
 }
 
 var compiledImageURLRegex *regexp.Regexp
 
 // Validate checks if the external service is well defined.
-func (s *ExternalService) Validate(knownServices []string) (err error) {
-	errPrefix := "failed to validate external service " + s.Name + ": "
-
+func (s *ExternalService) Validate(knownServices []string) error {
+	var errs ValidationErrors
 	if s.RepositoryURL == "" {
-		return errors.New(errPrefix + "missing repository URL")
+		errs.addf("repository_url", "missing repository URL")
 	}
 
-	if err = s.ServiceCommon.Validate(knownServices); err != nil {
-		return errors.New(errPrefix + err.Error())
-	}
+	errs.add("", s.ServiceCommon.Validate(knownServices))
 
-	_, err = validateWithRegex(
+	_, err := validateWithRegex(
 		s.ImageURL,
 		"image URL",
 		&compiledImageURLRegex,
 		`(https?://)?(([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]*[a-z0-9])?)(:[0-9]+)?(/[a-z0-9]([a-z0-9-]*[a-z0-9])?)+(:((v?[0-9]+(\.[0-9]+(\.[0-9]+)?)?)|[0-9a-f]+|latest|master))?`,
 	)
 	if err != nil {
-		return errors.New(errPrefix + "invalid image URL: " + err.Error())
+		errs.addf("image_url", "invalid image URL: %v", err)
 	}
-
-	return nil
+	return errs.err()
 }
 
 // ServiceCommon contains the core attributes of both saas-y and external services.
 type ServiceCommon struct {
-	Name          string     `json:"name"`
-	RepositoryURL string     `json:"repository_url"`
-	Port          string     `json:"port"`
-	Environment   []Variable `json:"env"`
-	Dependencies  []string   `json:"dependencies"`
+	Name          string     `json:"name" yaml:"name"`
+	RepositoryURL string     `json:"repository_url" yaml:"repository_url"`
+	Port          string     `json:"port" yaml:"port"`
+	Environment   []Variable `json:"env" yaml:"env"`
+	Dependencies  []string   `json:"dependencies" yaml:"dependencies"`
+	Source        string     `json:"-" yaml:"-"` // location in the spec files, set by the parser
 }
 
 // Validate checks if the service core attributes are well defined.
-func (s *ServiceCommon) Validate(knownServices []string) (err error) {
+// Environment variables may be of any of the given enum types.
+func (s *ServiceCommon) Validate(knownServices []string, enums ...Enum) error {
+	var errs ValidationErrors
 	// also allow dashes in service names
 	name := strings.ReplaceAll(s.Name, "-", "_")
-	if err = ValidateName(name, "service name"); err != nil {
-		return
-	}
+	errs.add("name", ValidateName(name, "service name"))
 
 	port, err := strconv.ParseInt(s.Port, 10, 32)
 	if err != nil || int(port) > 65535 {
-		return fmt.Errorf("invalid port value %s", s.Port)
+		errs.addf("port", "invalid port value %s", s.Port)
 	}
 
-	for _, v := range s.Environment {
-		if err = v.Validate(); err != nil {
-			return
+	for i, v := range s.Environment {
+		path := index("env", i)
+		errs.add(path, v.Validate(enums...))
+		if v.Required || v.Optional || v.HasConstraints() {
+			errs.addf(path, "environment variable %s cannot have presence or value constraints", v.Name)
+		}
+		if ElementType(v.Type) == "bytes" {
+			errs.addf(path+".type", "environment variable %s cannot be of type %s, use string instead", v.Name, v.Type)
 		}
 	}
 
-	for _, d := range s.Dependencies {
+	for i, d := range s.Dependencies {
 		found := false
 		for _, s := range knownServices {
 			if d == s {
@@ -445,10 +1199,10 @@ func (s *ServiceCommon) Validate(knownServices []string) (err error) {
 			}
 		}
 		if !found {
-			return fmt.Errorf("unknown dependency %s", d)
+			errs.addf(index("dependencies", i), "unknown dependency %s", d)
 		}
 	}
-	return
+	return errs.err()
 }
 
 // validateWithRegex validates a value with the given regex.
@@ -1,6 +1,7 @@
 package model_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -71,6 +72,23 @@ func TestMethodTypes(t *testing.T) {
 		{&model.Method{Type: "POST", InputType: "something_known"}, true},
 		{&model.Method{Type: "POST", ReturnType: "something_unknown"}, false},
 		{&model.Method{Type: "POST", ReturnType: "something_known"}, true},
+		{&model.Method{Type: "POST", InputType: "[]something_known"}, false},
+		{&model.Method{Type: "POST", ReturnType: "[]something_unknown"}, false},
+		{&model.Method{Type: "POST", ReturnType: "[]something_known"}, true},
+		{&model.Method{Type: "POST", ReturnType: "[]string"}, true},
+		{&model.Method{Type: "POST", ReturnType: "map<string,something_known>"}, true},
+		{&model.Method{Type: "POST", ReturnType: "map<string,int>"}, true},
+		{&model.Method{Type: "POST", ReturnType: "map<bool,int>"}, false},
+		{&model.Method{Type: "POST", InputType: "map<string,something_known>"}, false},
+		{&model.Method{Type: "GET", QueryParams: []model.Variable{{Name: "good_name", Type: "map<string,int>"}}}, false},
+		{&model.Method{Type: "GET", QueryParams: []model.Variable{{Name: "good_name", Type: "[]int"}}}, true},
+		{&model.Method{Type: "GET", HeaderParams: []model.Variable{{Name: "good_name", Type: "[]int"}}}, false},
+		{&model.Method{Type: "GET", QueryParams: []model.Variable{{Name: "good_name", Type: "int", Required: true}}}, true},
+		{&model.Method{Type: "GET", QueryParams: []model.Variable{{Name: "good_name", Type: "string", Pattern: "^a$"}}}, false},
+		{&model.Method{Type: "GET", Middleware: []string{"auth", "rate_limit"}}, true},
+		{&model.Method{Type: "WS", Middleware: []string{"auth"}}, true},
+		{&model.Method{Type: "GET", Middleware: []string{"rate-limit"}}, false},
+		{&model.Method{Type: "GET", Middleware: []string{"auth", "auth"}}, false},
 	}
 
 	for _, tt := range tests {
@@ -103,6 +121,9 @@ func TestPathRegex(t *testing.T) {
 		{"/some/valid/path/", 17, true},
 		{"/some/{valid:int}/path/", 23, true},
 		{"/some/valid/{path:string}/", 26, true},
+		{"/some/{valid:uuid}/{path:time}", 30, true},
+		{"/some/{valid:bool}/{path:duration}", 34, true},
+		{"/some/{invalid:bytes}/path", 6, false},
 		{"/", 1, true},
 	}
 
@@ -236,6 +257,22 @@ func TestVariableValid(t *testing.T) {
 		{&model.Variable{Name: "good_name_42", Type: "uint", Value: "1000000000"}, true},
 		{&model.Variable{Name: "good_name_42", Type: "uint", Value: "37"}, true},
 		{&model.Variable{Name: "good_name_42", Type: "uint", Value: ""}, true},
+		{&model.Variable{Name: "good_name_42", Type: "[]uint", Value: "1,2,3"}, true},
+		{&model.Variable{Name: "good_name_42", Type: "[]uint", Value: "1,-2,3"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "[]string", Value: "a,b"}, true},
+		{&model.Variable{Name: "good_name_42", Type: "[]bad_type", Value: "a,b"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "[]float", Value: ""}, true},
+		{&model.Variable{Name: "good_name_42", Type: "bool", Value: "true"}, true},
+		{&model.Variable{Name: "good_name_42", Type: "bool", Value: "yes"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "bytes", Value: "aGVsbG8="}, true},
+		{&model.Variable{Name: "good_name_42", Type: "bytes", Value: "hello!"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "time", Value: "2020-01-02T15:04:05Z"}, true},
+		{&model.Variable{Name: "good_name_42", Type: "time", Value: "2020-01-02 15:04:05"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "duration", Value: "1h30m"}, true},
+		{&model.Variable{Name: "good_name_42", Type: "duration", Value: "90"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "uuid", Value: "123e4567-e89b-12d3-a456-426614174000"}, true},
+		{&model.Variable{Name: "good_name_42", Type: "uuid", Value: "123e4567-e89b-12d3-a456"}, false},
+		{&model.Variable{Name: "good_name_42", Type: "[]duration", Value: "1s,2m"}, true},
 	}
 
 	for _, tt := range tests {
@@ -258,6 +295,77 @@ var badVariables = []model.Variable{
 	{Name: "good_name_42", Type: "bad_type", Value: "dummy_value"},
 }
 
+func TestVariableConstraints(t *testing.T) {
+	zero, one, ten := 0.0, 1.0, 10.0
+	negative, three, five := -1, 3, 5
+
+	tests := []struct {
+		variable *model.Variable
+		valid    bool
+	}{
+		{&model.Variable{Name: "age", Type: "int", Min: &zero, Max: &ten}, true},
+		{&model.Variable{Name: "ages", Type: "[]uint", Min: &one}, true},
+		{&model.Variable{Name: "age", Type: "int", Min: &ten, Max: &one}, false},
+		{&model.Variable{Name: "name", Type: "string", Min: &one}, false},
+		{&model.Variable{Name: "name", Type: "string", MinLength: &three, MaxLength: &five, Pattern: "^[a-z]+$"}, true},
+		{&model.Variable{Name: "names", Type: "[]string", MaxLength: &five, Pattern: "^[a-z]+$"}, true},
+		{&model.Variable{Name: "labels", Type: "map<string,string>", MaxLength: &five, Pattern: "^[a-z]+$"}, true},
+		{&model.Variable{Name: "counts", Type: "map<string,int>", Min: &one}, true},
+		{&model.Variable{Name: "name", Type: "string", MinLength: &five, MaxLength: &three}, false},
+		{&model.Variable{Name: "name", Type: "string", MinLength: &negative}, false},
+		{&model.Variable{Name: "age", Type: "int", MaxLength: &five}, false},
+		{&model.Variable{Name: "age", Type: "int", Pattern: "^[0-9]+$"}, false},
+		{&model.Variable{Name: "name", Type: "string", Pattern: "[a-z"}, false},
+		{&model.Variable{Name: "name", Type: "string", Required: true}, true},
+		{&model.Variable{Name: "name", Type: "string", Required: true, Optional: true}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.variable.Validate()
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestStructFieldPresence(t *testing.T) {
+	knownTypes := []string{"address", "color"}
+	enums := []model.Enum{{Name: "color", Values: []string{"red"}}}
+
+	tests := []struct {
+		field model.Variable
+		valid bool
+	}{
+		{model.Variable{Name: "name", Type: "string", Required: true}, true},
+		{model.Variable{Name: "color", Type: "color", Required: true}, true},
+		{model.Variable{Name: "tags", Type: "[]string", Required: true}, true},
+		{model.Variable{Name: "address", Type: "*address", Required: true}, true},
+		{model.Variable{Name: "age", Type: "int", Required: true}, false},
+		{model.Variable{Name: "address", Type: "address", Required: true}, false},
+		{model.Variable{Name: "age", Type: "int", Optional: true}, true},
+		{model.Variable{Name: "address", Type: "address", Optional: true}, true},
+		{model.Variable{Name: "address", Type: "*address", Optional: true}, false},
+		{model.Variable{Name: "id", Type: "uuid", Required: true}, true},
+		{model.Variable{Name: "at", Type: "time", Required: true}, true},
+		{model.Variable{Name: "payload", Type: "bytes", Required: true}, true},
+		{model.Variable{Name: "public", Type: "bool", Required: true}, false},
+		{model.Variable{Name: "lasted", Type: "duration", Required: true}, false},
+		{model.Variable{Name: "labels", Type: "map<string,string>", Required: true}, true},
+	}
+
+	for _, tt := range tests {
+		s := &model.Struct{Name: "user", Fields: []model.Variable{tt.field}}
+		err := s.Validate(knownTypes, enums...)
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
 func TestStructValid(t *testing.T) {
 	tests := []struct {
 		s     *model.Struct
@@ -270,7 +378,389 @@ func TestStructValid(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		err := tt.s.Validate()
+		err := tt.s.Validate([]string{})
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestVariableFieldType(t *testing.T) {
+	tests := []struct {
+		v         model.Variable
+		fieldType string
+	}{
+		{model.Variable{Type: "int"}, "int"},
+		{model.Variable{Type: "int", Optional: true}, "*int"},
+		{model.Variable{Type: "time", Optional: true}, "*time"},
+		{model.Variable{Type: "bytes", Optional: true}, "bytes"},
+		{model.Variable{Type: "[]string", Optional: true}, "[]string"},
+		{model.Variable{Type: "map<string,int>", Optional: true}, "map<string,int>"},
+		{model.Variable{Type: "*customer"}, "*customer"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.fieldType, tt.v.FieldType(), tt.v.Type)
+	}
+}
+
+func TestStructFieldTypes(t *testing.T) {
+	knownTypes := []string{"customer", "order"}
+
+	tests := []struct {
+		s     *model.Struct
+		valid bool
+	}{
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "customer", Type: "customer"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "customer", Type: "*customer"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "customers", Type: "[]customer"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "customers", Type: "[]*customer"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "supplier", Type: "supplier"}}}, false},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "supplier", Type: "*supplier"}}}, false},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "count", Type: "*int"}}}, false},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "count", Type: "int"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "labels", Type: "map<string,string>"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "customers", Type: "map<int, *customer>"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "groups", Type: "map<string,[]customer>"}}}, true},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "suppliers", Type: "map<string,supplier>"}}}, false},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "customers", Type: "map<customer,string>"}}}, false},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "weights", Type: "map<float,string>"}}}, false},
+		{&model.Struct{Name: "order", Fields: []model.Variable{{Name: "labels", Type: "map<string>"}}}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.s.Validate(knownTypes)
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestServiceStructCycles(t *testing.T) {
+	newService := func(structs ...model.Struct) *model.Service {
+		return &model.Service{
+			ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+			Structs:       structs,
+		}
+	}
+
+	tests := []struct {
+		svc   *model.Service
+		valid bool
+	}{
+		{newService(
+			model.Struct{Name: "order", Fields: []model.Variable{{Name: "customer", Type: "customer"}}},
+			model.Struct{Name: "customer", Fields: []model.Variable{{Name: "name", Type: "string"}}},
+		), true},
+		{newService(
+			model.Struct{Name: "node", Fields: []model.Variable{{Name: "next", Type: "node"}}},
+		), false},
+		{newService(
+			model.Struct{Name: "node", Fields: []model.Variable{{Name: "next", Type: "*node"}}},
+		), true},
+		{newService(
+			model.Struct{Name: "node", Fields: []model.Variable{{Name: "children", Type: "[]node"}}},
+		), true},
+		{newService(
+			model.Struct{Name: "order", Fields: []model.Variable{{Name: "customer", Type: "customer"}}},
+			model.Struct{Name: "customer", Fields: []model.Variable{{Name: "last_order", Type: "order"}}},
+		), false},
+		{newService(
+			model.Struct{Name: "order", Fields: []model.Variable{{Name: "customer", Type: "customer"}}},
+			model.Struct{Name: "customer", Fields: []model.Variable{{Name: "last_order", Type: "*order"}}},
+		), true},
+	}
+
+	for _, tt := range tests {
+		err := tt.svc.Validate([]string{}, []string{})
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestServiceSharedStructs(t *testing.T) {
+	sharedTypes := []string{"user"}
+
+	tests := []struct {
+		svc   *model.Service
+		valid bool
+	}{
+		{&model.Service{
+			ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+			Structs:       []model.Struct{{Name: "order", Fields: []model.Variable{{Name: "buyer", Type: "user"}}}},
+		}, true},
+		{&model.Service{
+			ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+			API:           []model.API{{Path: "/users", Methods: map[string]model.Method{"get_users": {Type: "GET", ReturnType: "[]user"}}}},
+		}, true},
+		{&model.Service{
+			ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+			Structs:       []model.Struct{{Name: "user", Fields: goodVariables}},
+		}, false},
+		{&model.Service{
+			ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+			Structs:       []model.Struct{{Name: "order", Fields: []model.Variable{{Name: "buyer", Type: "customer"}}}},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.svc.Validate([]string{}, sharedTypes)
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestSpecSharedStructs(t *testing.T) {
+	tests := []struct {
+		spec  *model.Spec
+		valid bool
+	}{
+		{&model.Spec{
+			RepositoryURL: "example.com/repo",
+			Structs: []model.Struct{
+				{Name: "user", Fields: []model.Variable{{Name: "address", Type: "address"}}},
+				{Name: "address", Fields: goodVariables},
+			},
+		}, true},
+		{&model.Spec{
+			RepositoryURL: "example.com/repo",
+			Structs: []model.Struct{
+				{Name: "user", Fields: []model.Variable{{Name: "address", Type: "address"}}},
+			},
+		}, false},
+		{&model.Spec{
+			RepositoryURL: "example.com/repo",
+			Structs: []model.Struct{
+				{Name: "user", Fields: []model.Variable{{Name: "address", Type: "address"}}},
+				{Name: "address", Fields: []model.Variable{{Name: "owner", Type: "user"}}},
+			},
+		}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.spec.Validate()
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestSpecAuth(t *testing.T) {
+	spec := func(auth model.Auth, level model.AuthLevel) *model.Spec {
+		return &model.Spec{
+			RepositoryURL: "example.com/repo",
+			Auth:          auth,
+			Services: []model.Service{{
+				ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+				API: []model.API{{
+					Path:    "/users",
+					Methods: map[string]model.Method{"get_users": {Type: model.GET, Auth: level}},
+				}},
+			}},
+		}
+	}
+
+	tests := []struct {
+		spec  *model.Spec
+		valid bool
+	}{
+		{spec(model.Auth{}, ""), true},
+		{spec(model.Auth{}, model.AuthNone), true},
+		{spec(model.Auth{}, model.AuthRequired), false},
+		{spec(model.Auth{Type: model.AuthTypeNone}, model.AuthOptional), false},
+		{spec(model.Auth{Type: model.AuthTypeAPIKey}, ""), true},
+		{spec(model.Auth{Type: model.AuthTypeAPIKey, Header: "X-Service-Key"}, model.AuthOptional), true},
+		{spec(model.Auth{Type: model.AuthTypeAPIKey, Header: "X Service Key"}, ""), false},
+		{spec(model.Auth{Type: model.AuthTypeAPIKey, Algorithm: "HS256"}, ""), false},
+		{spec(model.Auth{Type: model.AuthTypeJWT, Algorithm: "HS256"}, model.AuthRequired), true},
+		{spec(model.Auth{Type: model.AuthTypeJWT, Algorithm: "RS512"}, model.AuthNone), true},
+		{spec(model.Auth{Type: model.AuthTypeJWT}, ""), false},
+		{spec(model.Auth{Type: model.AuthTypeJWT, Algorithm: "none"}, ""), false},
+		{spec(model.Auth{Type: model.AuthTypeJWT, Algorithm: "HS256", Header: "X-Token"}, ""), false},
+		{spec(model.Auth{Type: "basic"}, ""), false},
+		{spec(model.Auth{Type: model.AuthTypeJWT, Algorithm: "HS256"}, "always"), false},
+	}
+
+	for _, tt := range tests {
+		err := tt.spec.Validate()
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+
+	err := spec(model.Auth{}, model.AuthRequired).Validate()
+	require.EqualError(t, err, "services[0].api[0].methods.get_users.auth: auth required is not allowed without an auth section in the spec")
+}
+
+func TestServiceDeducedFields(t *testing.T) {
+	// the fields deduced from the spec are not read from the services
+	var svc model.Service
+	err := json.Unmarshal([]byte(`{"name": "foo", "auth": {"type": "jwt"}, "singlemodule": true, "sharedrepositoryurl": "example.com/x"}`), &svc)
+	require.NoError(t, err)
+	require.Equal(t, "foo", svc.Name)
+	require.Equal(t, model.Auth{}, svc.Auth)
+	require.False(t, svc.SingleModule)
+	require.Empty(t, svc.SharedRepositoryURL)
+}
+
+func TestMethodAuthLevel(t *testing.T) {
+	jwt := model.Auth{Type: model.AuthTypeJWT, Algorithm: "HS256"}
+	require.Equal(t, model.AuthRequired, model.Method{}.AuthLevel(jwt))
+	require.Equal(t, model.AuthOptional, model.Method{Auth: model.AuthOptional}.AuthLevel(jwt))
+	require.Equal(t, model.AuthNone, model.Method{Auth: model.AuthNone}.AuthLevel(jwt))
+	require.Equal(t, model.AuthNone, model.Method{}.AuthLevel(model.Auth{}))
+}
+
+func TestCORSValid(t *testing.T) {
+	tests := []struct {
+		cors  *model.CORS
+		valid bool
+	}{
+		{&model.CORS{AllowedOrigins: []string{"*"}}, true},
+		{&model.CORS{AllowedOrigins: []string{"https://example.com", "http://localhost:3000"}, AllowCredentials: true}, true},
+		{&model.CORS{
+			AllowedOrigins: []string{"https://app.example.com"},
+			AllowedMethods: []string{"GET", "POST"},
+			AllowedHeaders: []string{"Content-Type", "X-Request-Id"},
+			MaxAge:         600,
+		}, true},
+		{&model.CORS{}, false},
+		{&model.CORS{AllowedOrigins: []string{"*"}, AllowCredentials: true}, false},
+		{&model.CORS{AllowedOrigins: []string{"example.com"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"ftp://example.com"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"https://example.com/"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"https://example.com?a=b"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"https://example.com#"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"https://user@example.com"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"get"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"CONNECT"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"*"}, AllowedHeaders: []string{"X Request Id"}}, false},
+		{&model.CORS{AllowedOrigins: []string{"*"}, MaxAge: -1}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.cors.Validate()
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+
+	svc := model.Service{
+		ServiceCommon: model.ServiceCommon{Name: "good_service_name", Port: "80"},
+		CORS:          &model.CORS{AllowedOrigins: []string{"https://example.com", "example.com"}},
+	}
+	require.EqualError(t, svc.Validate(nil, nil), "cors.allowed_origins[1]: invalid origin example.com, expected a scheme and a host, e.g. https://example.com, or *")
+}
+
+func TestEnumValid(t *testing.T) {
+	tests := []struct {
+		enum  *model.Enum
+		valid bool
+	}{
+		{&model.Enum{Name: "order_status", Values: []string{"pending", "in_progress", "DONE"}}, true},
+		{&model.Enum{Name: "color", Values: []string{"dark-blue"}}, true},
+		{&model.Enum{Name: "order_status_", Values: []string{"pending"}}, false},
+		{&model.Enum{Name: "order_status", Values: []string{}}, false},
+		{&model.Enum{Name: "order_status", Values: []string{"pending", "pending"}}, false},
+		{&model.Enum{Name: "order_status", Values: []string{"in progress"}}, false},
+		{&model.Enum{Name: "order_status", Values: []string{"_pending"}}, false},
+	}
+
+	for _, tt := range tests {
+		err := tt.enum.Validate()
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestServiceEnums(t *testing.T) {
+	enums := []model.Enum{{Name: "order_status", Values: []string{"pending", "done"}}}
+	api := func(path string, method model.Method) []model.API {
+		return []model.API{{Path: path, Methods: map[string]model.Method{"method": method}}}
+	}
+
+	tests := []struct {
+		svc   *model.Service
+		valid bool
+	}{
+		{&model.Service{API: api("/orders/{status:order_status}", model.Method{Type: model.GET})}, true},
+		{&model.Service{API: api("/orders/{status:order_state}", model.Method{Type: model.GET})}, false},
+		{&model.Service{API: api("/orders", model.Method{
+			Type:         model.GET,
+			QueryParams:  []model.Variable{{Name: "statuses", Type: "[]order_status"}},
+			HeaderParams: []model.Variable{{Name: "status", Type: "order_status"}},
+		})}, true},
+		{&model.Service{API: api("/orders", model.Method{
+			Type:        model.GET,
+			QueryParams: []model.Variable{{Name: "statuses", Type: "[]order_state"}},
+		})}, false},
+		{&model.Service{Structs: []model.Struct{{Name: "order", Fields: []model.Variable{{Name: "status", Type: "order_status"}}}}}, true},
+		{&model.Service{ServiceCommon: model.ServiceCommon{Environment: []model.Variable{{Name: "status", Type: "order_status", Value: "done"}}}}, true},
+		{&model.Service{ServiceCommon: model.ServiceCommon{Environment: []model.Variable{{Name: "status", Type: "order_status", Value: "gone"}}}}, false},
+		{&model.Service{Structs: []model.Struct{{Name: "order_status", Fields: goodVariables}}}, false},
+	}
+
+	for _, tt := range tests {
+		tt.svc.Name = "good_service_name"
+		tt.svc.Port = "80"
+		tt.svc.Enums = enums
+		err := tt.svc.Validate([]string{}, []string{})
+		if tt.valid {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestServiceErrors(t *testing.T) {
+	structs := []model.Struct{{Name: "order", Fields: goodVariables}, {Name: "order_error", Fields: goodVariables}}
+	enums := []model.Enum{{Name: "order_status", Values: []string{"pending", "done"}}}
+
+	tests := []struct {
+		errors []model.ErrorKind
+		valid  bool
+	}{
+		{[]model.ErrorKind{{Name: "order_not_found", Status: 404}}, true},
+		{[]model.ErrorKind{{Name: "order_locked", Status: 409, Payload: "order"}, {Name: "unavailable", Status: 503}}, true},
+		{[]model.ErrorKind{{Name: "order_not_found", Status: 200}}, false},
+		{[]model.ErrorKind{{Name: "order_not_found", Status: 600}}, false},
+		{[]model.ErrorKind{{Name: "order_not_found"}}, false},
+		{[]model.ErrorKind{{Name: "order-not-found", Status: 404}}, false},
+		{[]model.ErrorKind{{Name: "validation", Status: 400}}, false},
+		{[]model.ErrorKind{{Name: "internal", Status: 500}}, false},
+		{[]model.ErrorKind{{Name: "order_locked", Status: 409, Payload: "orders"}}, false},
+		{[]model.ErrorKind{{Name: "order_locked", Status: 409, Payload: "order_status"}}, false},
+		{[]model.ErrorKind{{Name: "order_locked", Status: 409, Payload: "string"}}, false},
+		{[]model.ErrorKind{{Name: "order_not_found", Status: 404}, {Name: "order_not_found", Status: 410}}, false},
+		{[]model.ErrorKind{{Name: "order_status", Status: 409}}, true},
+		{[]model.ErrorKind{{Name: "order", Status: 409}}, false},
+	}
+
+	for _, tt := range tests {
+		svc := &model.Service{Structs: structs, Enums: enums, Errors: tt.errors}
+		svc.Name = "good_service_name"
+		svc.Port = "80"
+		err := svc.Validate([]string{}, []string{})
 		if tt.valid {
 			require.NoError(t, err)
 		} else {
@@ -360,3 +850,47 @@ func TestExternalServiceValid(t *testing.T) {
 		}
 	}
 }
+
+func TestSpecValidationErrors(t *testing.T) {
+	spec := &model.Spec{
+		Subdomains: []model.Subdomain{
+			{Name: "api", Paths: []model.Path{{Value: "/foo", Endpoint: "foo-service"}, {Value: "/bar", Endpoint: "bar-service"}}},
+		},
+		Services: []model.Service{
+			{
+				ServiceCommon: model.ServiceCommon{Name: "foo-service", Port: "80"},
+				API: []model.API{
+					{
+						Path: "/users",
+						Methods: map[string]model.Method{
+							"get_user":    {Type: model.GET, ReturnType: "user"},
+							"delete_user": {Type: "REMOVE", InputType: "user"},
+						},
+					},
+				},
+				Structs: []model.Struct{
+					{Name: "User", Fields: []model.Variable{{Name: "age", Type: "int", Required: true, Pattern: "^[0-9]+$"}}},
+				},
+			},
+		},
+	}
+
+	err := spec.Validate()
+	require.IsType(t, model.ValidationErrors{}, err)
+
+	var paths []string
+	for _, e := range err.(model.ValidationErrors) {
+		paths = append(paths, e.Path)
+	}
+	require.Equal(t, []string{
+		"repository_url",
+		"subdomains[0].paths[1].endpoint",
+		"services[0].structs[0].name",
+		"services[0].structs[0].fields[0].pattern",
+		"services[0].structs[0].fields[0].required",
+		"services[0].api[0].methods.delete_user.type",
+		"services[0].api[0].methods.delete_user.input_type",
+		"services[0].api[0].methods.get_user.return_type",
+	}, paths)
+	require.Contains(t, err.Error(), "\nservices[0].api[0].methods.delete_user.type: invalid method type REMOVE\n")
+}
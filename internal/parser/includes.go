@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/popescu-af/saas-y/internal/model"
+)
+
+// fileParser is implemented by the parsers of all formats, decoding
+// the contents of a single spec file without resolving its includes.
+type fileParser interface {
+	Abstract
+	decode(b []byte, spec *model.Spec) error
+	positions(b []byte) (positions, error)
+}
+
+// parseWithIncludes decodes the root spec file with the given decoder and merges into it
+// the services, external services, subdomains, structs and enums of the files it includes.
+// Include patterns are globs resolved relative to the including file, which can in turn
+// include other files; every entry remembers the file it comes from.
+func parseWithIncludes(filename string, p fileParser) (*model.Spec, error) {
+	m := &merger{
+		spec:     &model.Spec{Source: filename, Positions: make(map[string]map[string]model.Position)},
+		included: make(map[string]string),
+		services: make(map[string]string),
+		types:    make(map[string]string),
+		paths:    make(map[string]string),
+	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, err
+	}
+	m.included[abs] = filename
+
+	root, err := m.decodeFile(filename, p)
+	if err != nil {
+		return nil, err
+	}
+
+	m.spec.RepositoryURL = root.RepositoryURL
+	m.spec.Domain = root.Domain
+	m.spec.SingleModule = root.SingleModule
+	m.spec.Auth = root.Auth
+	if err = m.merge(filename, root); err != nil {
+		return nil, err
+	}
+	return m.spec, nil
+}
+
+// merger accumulates the entries of all spec files, remembering
+// where every named entry comes from to report duplicates.
+type merger struct {
+	spec     *model.Spec
+	included map[string]string // absolute path -> including file
+	services map[string]string // service name -> source
+	types    map[string]string // shared struct or enum name -> source
+	paths    map[string]string // subdomain name and path value -> source
+}
+
+func (m *merger) decodeFile(filename string, p fileParser) (*model.Spec, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &model.Spec{}
+	if err = p.decode(b, spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", filename, err)
+	}
+
+	if m.spec.Positions[filename], err = p.positions(b); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", filename, err)
+	}
+	return spec, nil
+}
+
+func (m *merger) merge(filename string, spec *model.Spec) (err error) {
+	for i, svc := range spec.Services {
+		svc.Source = source(filename, "services", i)
+		if err = m.claim(m.services, svc.Name, "service "+svc.Name, svc.Source); err != nil {
+			return
+		}
+		m.spec.Services = append(m.spec.Services, svc)
+	}
+
+	for i, esvc := range spec.ExternalServices {
+		esvc.Source = source(filename, "external_services", i)
+		if err = m.claim(m.services, esvc.Name, "service "+esvc.Name, esvc.Source); err != nil {
+			return
+		}
+		m.spec.ExternalServices = append(m.spec.ExternalServices, esvc)
+	}
+
+	for i, st := range spec.Structs {
+		st.Source = source(filename, "structs", i)
+		if err = m.claim(m.types, st.Name, "shared type "+st.Name, st.Source); err != nil {
+			return
+		}
+		m.spec.Structs = append(m.spec.Structs, st)
+	}
+
+	for i, e := range spec.Enums {
+		e.Source = source(filename, "enums", i)
+		if err = m.claim(m.types, e.Name, "shared type "+e.Name, e.Source); err != nil {
+			return
+		}
+		m.spec.Enums = append(m.spec.Enums, e)
+	}
+
+	for i, subd := range spec.Subdomains {
+		if err = m.mergeSubdomain(subd, source(filename, "subdomains", i)); err != nil {
+			return
+		}
+	}
+
+	return m.mergeIncludes(filename, spec.Include)
+}
+
+// mergeSubdomain adds the paths of a subdomain to the subdomain with the
+// same name, if any, so that every file can route to its own services.
+func (m *merger) mergeSubdomain(subd model.Subdomain, src string) error {
+	var target *model.Subdomain
+	for i := range m.spec.Subdomains {
+		if m.spec.Subdomains[i].Name == subd.Name {
+			target = &m.spec.Subdomains[i]
+			break
+		}
+	}
+	if target == nil {
+		m.spec.Subdomains = append(m.spec.Subdomains, model.Subdomain{Name: subd.Name, Source: src})
+		target = &m.spec.Subdomains[len(m.spec.Subdomains)-1]
+	}
+
+	for i, p := range subd.Paths {
+		p.Source = fmt.Sprintf("%s.paths[%d]", src, i)
+		if err := m.claim(m.paths, subd.Name+p.Value, "path "+p.Value+" of subdomain "+subd.Name, p.Source); err != nil {
+			return err
+		}
+		target.Paths = append(target.Paths, p)
+	}
+	return nil
+}
+
+func (m *merger) mergeIncludes(filename string, patterns []string) error {
+	dir := filepath.Dir(filename)
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return fmt.Errorf("%s: invalid include pattern %s: %v", filename, pattern, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("%s: include pattern %s matches no files", filename, pattern)
+		}
+
+		for _, match := range matches {
+			abs, err := filepath.Abs(match)
+			if err != nil {
+				return err
+			}
+			if includer, ok := m.included[abs]; ok {
+				return fmt.Errorf("%s: %s is already included by %s", filename, match, includer)
+			}
+			m.included[abs] = filename
+
+			p, err := ForFile(match)
+			if err != nil {
+				return fmt.Errorf("%s: %v", filename, err)
+			}
+
+			spec, err := m.decodeFile(match, p.(fileParser))
+			if err != nil {
+				return err
+			}
+			if spec.RepositoryURL != "" || spec.Domain != "" || spec.SingleModule || spec.Auth != (model.Auth{}) {
+				return fmt.Errorf("%s: repository_url, domain, single_module and auth are only allowed in the root spec file", match)
+			}
+
+			if err = m.merge(match, spec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// claim records the source of an entry, failing if its key is already taken.
+func (m *merger) claim(sources map[string]string, key, what, src string) error {
+	if previous, ok := sources[key]; ok {
+		return fmt.Errorf("%s: duplicate %s, already defined at %s", src, what, previous)
+	}
+	sources[key] = src
+	return nil
+}
+
+func source(filename, list string, index int) string {
+	return fmt.Sprintf("%s, %s[%d]", filename, list, index)
+}
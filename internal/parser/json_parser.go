@@ -3,7 +3,6 @@ package parser
 import (
 	"bytes"
 	"encoding/json"
-	"io/ioutil"
 
 	"github.com/popescu-af/saas-y/internal/model"
 )
@@ -12,19 +11,22 @@ import (
 type JSON struct {
 }
 
-// Parse does the actual parsing, creating the Spec struct from a JSON file.
+// Parse does the actual parsing, creating the Spec struct from a JSON file
+// and the files it includes.
 func (j *JSON) Parse(filename string) (spec *model.Spec, err error) {
-	b, err := ioutil.ReadFile(filename)
+	spec, err = parseWithIncludes(filename, j)
 	if err != nil {
-		return
-	}
-
-	spec = &model.Spec{}
-	err = json.NewDecoder(bytes.NewBuffer(b)).Decode(spec)
-	if err != nil {
-		spec = nil
+		return nil, err
 	}
 
 	spec.GenerateAdditionalInformation()
 	return
 }
+
+func (j *JSON) decode(b []byte, spec *model.Spec) error {
+	return json.NewDecoder(bytes.NewBuffer(b)).Decode(spec)
+}
+
+func (j *JSON) positions(b []byte) (positions, error) {
+	return jsonPositions(b)
+}
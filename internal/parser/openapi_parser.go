@@ -0,0 +1,681 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/popescu-af/saas-y/internal/model"
+)
+
+// An OpenAPI imports a saas-y service from an OpenAPI 3 JSON document.
+// The document only describes the API of the service, the rest of the
+// spec being given by the fields below.
+type OpenAPI struct {
+	RepositoryURL string // where the SaaS code is kept, as in the spec
+	ServiceName   string // defaults to the document title, in kebab case
+	Port          string // defaults to 80
+}
+
+// Parse converts the paths, operations, parameters and component schemas of
+// the OpenAPI document into a spec holding a single service.
+// Schema and operation names are converted to snake case, while property and
+// parameter names are kept as they are, since they are part of the wire format,
+// those which are not snake case being reported as unsupported.
+func (o *OpenAPI) Parse(filename string) (*model.Spec, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &openAPIDocument{}
+	if err = json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", filename, err)
+	}
+
+	c := &openAPIConverter{doc: doc, enums: make(map[string]bool), resolving: make(map[string]bool)}
+	svc, err := c.convert()
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %v", filename, err)
+	}
+
+	svc.Name = o.ServiceName
+	if svc.Name == "" {
+		svc.Name = strings.ReplaceAll(toSnakeCase(doc.Info.Title), "_", "-")
+	}
+	svc.Port = o.Port
+	if svc.Port == "" {
+		svc.Port = "80"
+	}
+	svc.Source = filename
+
+	spec := &model.Spec{
+		RepositoryURL: o.RepositoryURL,
+		Services:      []model.Service{*svc},
+	}
+	spec.GenerateAdditionalInformation()
+	return spec, nil
+}
+
+// The subset of an OpenAPI 3 document which has a saas-y equivalent.
+// Constructs without one are decoded as well, to be reported.
+
+type openAPIDocument struct {
+	OpenAPI string `json:"openapi"`
+	Info    struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	Paths      map[string]*openAPIPathItem `json:"paths"`
+	Components struct {
+		Schemas       map[string]*openAPISchema      `json:"schemas"`
+		Parameters    map[string]*openAPIParameter   `json:"parameters"`
+		RequestBodies map[string]*openAPIRequestBody `json:"requestBodies"`
+		Responses     map[string]*openAPIResponse    `json:"responses"`
+	} `json:"components"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `json:"parameters"`
+	Get        *openAPIOperation   `json:"get"`
+	Post       *openAPIOperation   `json:"post"`
+	Patch      *openAPIOperation   `json:"patch"`
+	Delete     *openAPIOperation   `json:"delete"`
+	Put        *openAPIOperation   `json:"put"`
+	Head       *openAPIOperation   `json:"head"`
+	Options    *openAPIOperation   `json:"options"`
+	Trace      *openAPIOperation   `json:"trace"`
+}
+
+type openAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Parameters  []*openAPIParameter         `json:"parameters"`
+	RequestBody *openAPIRequestBody         `json:"requestBody"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	Callbacks   json.RawMessage             `json:"callbacks"`
+}
+
+type openAPIParameter struct {
+	Ref      string          `json:"$ref"`
+	Name     string          `json:"name"`
+	In       string          `json:"in"`
+	Required bool            `json:"required"`
+	Schema   *openAPISchema  `json:"schema"`
+	Content  json.RawMessage `json:"content"`
+}
+
+type openAPIRequestBody struct {
+	Ref     string                       `json:"$ref"`
+	Content map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Ref     string                       `json:"$ref"`
+	Content map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string            `json:"$ref"`
+	Type                 string            `json:"type"`
+	Format               string            `json:"format"`
+	Enum                 []interface{}     `json:"enum"`
+	Items                *openAPISchema    `json:"items"`
+	Properties           openAPIProperties `json:"properties"`
+	Required             []string          `json:"required"`
+	AdditionalProperties json.RawMessage   `json:"additionalProperties"`
+	Minimum              *float64          `json:"minimum"`
+	Maximum              *float64          `json:"maximum"`
+	MinLength            *int              `json:"minLength"`
+	MaxLength            *int              `json:"maxLength"`
+	MinItems             *int              `json:"minItems"`
+	MaxItems             *int              `json:"maxItems"`
+	Pattern              string            `json:"pattern"`
+
+	ExclusiveMinimum json.RawMessage `json:"exclusiveMinimum"`
+	ExclusiveMaximum json.RawMessage `json:"exclusiveMaximum"`
+	AllOf            json.RawMessage `json:"allOf"`
+	OneOf            json.RawMessage `json:"oneOf"`
+	AnyOf            json.RawMessage `json:"anyOf"`
+	Not              json.RawMessage `json:"not"`
+}
+
+// openAPIProperties keeps the properties of a schema in document order,
+// which becomes the order of the struct fields.
+type openAPIProperties struct {
+	names   []string
+	schemas map[string]*openAPISchema
+}
+
+func (p *openAPIProperties) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &p.schemas); err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(b))
+	if _, err := d.Token(); err != nil {
+		return err
+	}
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		p.names = append(p.names, t.(string))
+
+		var value json.RawMessage
+		if err = d.Decode(&value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unsupportedKeyword returns the first keyword of the schema without a saas-y equivalent, if any.
+func (s *openAPISchema) unsupportedKeyword() string {
+	keywords := []struct {
+		name  string
+		value json.RawMessage
+	}{
+		{"exclusiveMinimum", s.ExclusiveMinimum},
+		{"exclusiveMaximum", s.ExclusiveMaximum},
+		{"allOf", s.AllOf},
+		{"oneOf", s.OneOf},
+		{"anyOf", s.AnyOf},
+		{"not", s.Not},
+	}
+	for _, k := range keywords {
+		if k.value != nil {
+			return k.name
+		}
+	}
+	return ""
+}
+
+func (s *openAPISchema) isStruct() bool {
+	return (s.Type == "object" || s.Type == "") && len(s.Properties.names) > 0
+}
+
+func (s *openAPISchema) isEnum() bool {
+	return s.Type == "string" && len(s.Enum) > 0
+}
+
+// openAPIConverter converts an OpenAPI document into a saas-y service.
+type openAPIConverter struct {
+	doc       *openAPIDocument
+	enums     map[string]bool // names of the converted enums
+	resolving map[string]bool // aliases being resolved, to detect cycles
+}
+
+func (c *openAPIConverter) convert() (*model.Service, error) {
+	if !strings.HasPrefix(c.doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, expected 3.x", c.doc.OpenAPI)
+	}
+
+	svc := &model.Service{}
+
+	schemas := c.doc.Components.Schemas
+	for _, name := range sortedKeys(schemas) {
+		if schemas[name].isEnum() {
+			e, err := c.convertEnum(name, schemas[name])
+			if err != nil {
+				return nil, err
+			}
+			svc.Enums = append(svc.Enums, e)
+			c.enums[e.Name] = true
+		}
+	}
+
+	for _, name := range sortedKeys(schemas) {
+		if schemas[name].isStruct() {
+			st, err := c.convertStruct(name, schemas[name])
+			if err != nil {
+				return nil, err
+			}
+			svc.Structs = append(svc.Structs, st)
+		}
+	}
+
+	for _, p := range sortedKeys(c.doc.Paths) {
+		api, err := c.convertPath(p, c.doc.Paths[p])
+		if err != nil {
+			return nil, err
+		}
+		if api != nil {
+			svc.API = append(svc.API, *api)
+		}
+	}
+	return svc, nil
+}
+
+func (c *openAPIConverter) convertEnum(name string, s *openAPISchema) (e model.Enum, err error) {
+	at := "components.schemas." + name
+
+	e.Name = toSnakeCase(name)
+	for i, v := range s.Enum {
+		value, ok := v.(string)
+		if !ok {
+			return e, unsupported(fmt.Sprintf("%s.enum[%d]", at, i), "non-string enum value %v", v)
+		}
+		e.Values = append(e.Values, value)
+	}
+	return
+}
+
+func (c *openAPIConverter) convertStruct(name string, s *openAPISchema) (st model.Struct, err error) {
+	at := "components.schemas." + name
+	if k := s.unsupportedKeyword(); k != "" {
+		return st, unsupported(at, "keyword %s", k)
+	}
+
+	required := make(map[string]bool)
+	for _, r := range s.Required {
+		required[r] = true
+	}
+
+	st.Name = toSnakeCase(name)
+	for _, pname := range s.Properties.names {
+		v, err := c.convertField(pname, s.Properties.schemas[pname], required[pname], at+".properties."+pname)
+		if err != nil {
+			return st, err
+		}
+		st.Fields = append(st.Fields, v)
+	}
+	return
+}
+
+// convertField converts a struct property. Required properties are required fields if their
+// presence can be checked, all others are optional. Numeric bounds and patterns of array
+// items apply to every element, as in saas-y.
+func (c *openAPIConverter) convertField(name string, s *openAPISchema, required bool, at string) (v model.Variable, err error) {
+	if err = checkName(name, "property", at); err != nil {
+		return
+	}
+	v.Name = name
+	if v.Type, err = c.typeOf(s, at); err != nil {
+		return
+	}
+
+	if required {
+		v.Required = model.HasDistinctZeroValue(v.Type) || c.enums[v.Type]
+	} else {
+		v.Optional = true
+	}
+
+	s = c.unalias(s)
+	element := s
+	if model.IsArrayType(v.Type) {
+		v.MinLength, v.MaxLength = s.MinItems, s.MaxItems
+		element = c.unalias(s.Items)
+		if element.MinLength != nil || element.MaxLength != nil {
+			return v, unsupported(at+".items", "length constraints of array items")
+		}
+	} else {
+		v.MinLength, v.MaxLength = s.MinLength, s.MaxLength
+	}
+	v.Min, v.Max, v.Pattern = element.Minimum, element.Maximum, element.Pattern
+	return
+}
+
+// typeOf returns the saas-y type of a schema. Components which are neither
+// structs nor enums are aliases, replaced by the type they stand for.
+func (c *openAPIConverter) typeOf(s *openAPISchema, at string) (string, error) {
+	if s == nil {
+		return "", fmt.Errorf("%s: missing schema", at)
+	}
+	if k := s.unsupportedKeyword(); k != "" {
+		return "", unsupported(at, "keyword %s", k)
+	}
+
+	if s.Ref != "" {
+		name, err := componentName(s.Ref, "schemas", at)
+		if err != nil {
+			return "", err
+		}
+		target, ok := c.doc.Components.Schemas[name]
+		if !ok {
+			return "", fmt.Errorf("%s: unknown schema %s", at, s.Ref)
+		}
+		if target.isStruct() || target.isEnum() {
+			return toSnakeCase(name), nil
+		}
+		if c.resolving[name] {
+			return "", unsupported(at, "recursive schema %s", s.Ref)
+		}
+		c.resolving[name] = true
+		defer delete(c.resolving, name)
+		return c.typeOf(target, "components.schemas."+name)
+	}
+
+	if len(s.Enum) > 0 {
+		return "", unsupported(at, "inline enum, define it under components.schemas")
+	}
+
+	switch s.Type {
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float", nil
+	case "boolean":
+		return "bool", nil
+	case "string":
+		switch s.Format {
+		case "date-time":
+			return "time", nil
+		case "uuid":
+			return "uuid", nil
+		case "byte":
+			return "bytes", nil
+		case "binary":
+			return "", unsupported(at, "binary string")
+		}
+		return "string", nil
+	case "array":
+		t, err := c.typeOf(s.Items, at+".items")
+		if err != nil {
+			return "", err
+		}
+		if model.IsContainerType(t) {
+			return "", unsupported(at, "nested container type")
+		}
+		return "[]" + t, nil
+	case "object", "":
+		if len(s.Properties.names) > 0 {
+			return "", unsupported(at, "inline object, define it under components.schemas")
+		}
+		additional := string(bytes.TrimSpace(s.AdditionalProperties))
+		if additional == "" || additional == "true" || additional == "{}" {
+			return "", unsupported(at, "free-form object")
+		}
+		values := &openAPISchema{}
+		if err := json.Unmarshal(s.AdditionalProperties, values); err != nil {
+			return "", fmt.Errorf("%s.additionalProperties: %v", at, err)
+		}
+		t, err := c.typeOf(values, at+".additionalProperties")
+		if err != nil {
+			return "", err
+		}
+		if model.IsContainerType(t) {
+			return "", unsupported(at, "nested container type")
+		}
+		return "map<string," + t + ">", nil
+	}
+	return "", unsupported(at, "schema type %s", s.Type)
+}
+
+// unalias returns the schema an alias stands for, which holds its constraints.
+func (c *openAPIConverter) unalias(s *openAPISchema) *openAPISchema {
+	for s.Ref != "" {
+		name, _ := componentName(s.Ref, "schemas", "")
+		target := c.doc.Components.Schemas[name]
+		if target.isStruct() || target.isEnum() {
+			break
+		}
+		s = target
+	}
+	return s
+}
+
+var openAPIPathParamRegex = regexp.MustCompile(`\{([^{}:]+)\}`)
+
+// convertPath converts the operations of a path into a saas-y API, or nil if there are none.
+func (c *openAPIConverter) convertPath(path string, item *openAPIPathItem) (*model.API, error) {
+	at := "paths." + path
+
+	for _, op := range []struct {
+		name string
+		op   *openAPIOperation
+	}{{"put", item.Put}, {"head", item.Head}, {"options", item.Options}, {"trace", item.Trace}} {
+		if op.op != nil {
+			return nil, unsupported(at+"."+op.name, "operation type %s, use post or patch instead of put", strings.ToUpper(op.name))
+		}
+	}
+
+	api := &model.API{Methods: make(map[string]model.Method)}
+	for _, op := range []struct {
+		t  model.APIMethodType
+		op *openAPIOperation
+	}{{model.GET, item.Get}, {model.POST, item.Post}, {model.PATCH, item.Patch}, {model.DELETE, item.Delete}} {
+		if op.op == nil {
+			continue
+		}
+		opAt := at + "." + strings.ToLower(string(op.t))
+
+		name := op.op.OperationID
+		if name == "" {
+			name = string(op.t) + " " + path
+		}
+		name = toSnakeCase(name)
+
+		apiPath, m, err := c.convertOperation(path, op.t, op.op, item.Parameters, opAt)
+		if err != nil {
+			return nil, err
+		}
+		if api.Path != "" && api.Path != apiPath {
+			return nil, unsupported(opAt, "path parameter types differing between operations")
+		}
+		api.Path = apiPath
+		api.Methods[name] = m
+	}
+
+	if len(api.Methods) == 0 {
+		return nil, nil
+	}
+	return api, nil
+}
+
+// convertOperation converts an operation into a saas-y method, returning also the path
+// with the types of its parameters, as saas-y expects them, e.g. /users/{id:uuid}.
+func (c *openAPIConverter) convertOperation(path string, t model.APIMethodType, op *openAPIOperation, common []*openAPIParameter, at string) (string, model.Method, error) {
+	m := model.Method{Type: t}
+	if op.Callbacks != nil {
+		return "", m, unsupported(at, "callbacks")
+	}
+
+	// operation parameters override the common parameters of the path
+	params := make(map[string]*openAPIParameter)
+	var order []string
+	for i, list := range [][]*openAPIParameter{common, op.Parameters} {
+		listAt := at + ".parameters"
+		if i == 0 {
+			listAt = "paths." + path + ".parameters"
+		}
+		for j, p := range list {
+			p, err := c.resolveParameter(p, fmt.Sprintf("%s[%d]", listAt, j))
+			if err != nil {
+				return "", m, err
+			}
+			key := p.In + " " + p.Name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = p
+		}
+	}
+
+	pathTypes := make(map[string]string)
+	for _, key := range order {
+		p := params[key]
+		pAt := at + ".parameters." + p.Name
+		if p.Content != nil {
+			return "", m, unsupported(pAt, "parameter content, use a schema instead")
+		}
+		pt, err := c.typeOf(p.Schema, pAt)
+		if err != nil {
+			return "", m, err
+		}
+
+		switch p.In {
+		case "path":
+			if model.IsContainerType(pt) {
+				return "", m, unsupported(pAt, "path parameter of type %s", pt)
+			}
+			pathTypes[p.Name] = pt
+		case "query":
+			if err := checkName(p.Name, "query parameter", pAt); err != nil {
+				return "", m, err
+			}
+			m.QueryParams = append(m.QueryParams, model.Variable{Name: p.Name, Type: pt, Required: p.Required})
+		case "header":
+			// header names are case-insensitive, but saas-y ones cannot have dashes
+			name := strings.ToLower(p.Name)
+			if strings.Contains(name, "-") {
+				return "", m, unsupported(pAt, "header name %s, saas-y header names are snake case", p.Name)
+			}
+			m.HeaderParams = append(m.HeaderParams, model.Variable{Name: name, Type: pt, Required: p.Required})
+		default:
+			return "", m, unsupported(pAt, "%s parameter", p.In)
+		}
+	}
+
+	var err error
+	apiPath := openAPIPathParamRegex.ReplaceAllStringFunc(path, func(s string) string {
+		name := s[1 : len(s)-1]
+		pt, ok := pathTypes[name]
+		if !ok && err == nil {
+			err = fmt.Errorf("%s: undeclared path parameter %s", at, name)
+		}
+		return "{" + name + ":" + pt + "}"
+	})
+	if err != nil {
+		return "", m, err
+	}
+
+	if m.InputType, err = c.convertRequestBody(op.RequestBody, at+".requestBody"); err != nil {
+		return "", m, err
+	}
+	if m.ReturnType, err = c.convertResponses(op.Responses, at+".responses"); err != nil {
+		return "", m, err
+	}
+	return apiPath, m, nil
+}
+
+func (c *openAPIConverter) resolveParameter(p *openAPIParameter, at string) (*openAPIParameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, err := componentName(p.Ref, "parameters", at)
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := c.doc.Components.Parameters[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("%s: unknown parameter %s", at, p.Ref)
+}
+
+func (c *openAPIConverter) convertRequestBody(body *openAPIRequestBody, at string) (string, error) {
+	if body == nil {
+		return "", nil
+	}
+	if body.Ref != "" {
+		name, err := componentName(body.Ref, "requestBodies", at)
+		if err != nil {
+			return "", err
+		}
+		resolved, ok := c.doc.Components.RequestBodies[name]
+		if !ok {
+			return "", fmt.Errorf("%s: unknown request body %s", at, body.Ref)
+		}
+		body = resolved
+	}
+	return c.convertContent(body.Content, at)
+}
+
+// convertResponses returns the type of the first successful response, if any.
+func (c *openAPIConverter) convertResponses(responses map[string]*openAPIResponse, at string) (string, error) {
+	for _, code := range sortedKeys(responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		r := responses[code]
+		if r.Ref != "" {
+			name, err := componentName(r.Ref, "responses", at+"."+code)
+			if err != nil {
+				return "", err
+			}
+			resolved, ok := c.doc.Components.Responses[name]
+			if !ok {
+				return "", fmt.Errorf("%s.%s: unknown response %s", at, code, r.Ref)
+			}
+			r = resolved
+		}
+		return c.convertContent(r.Content, at+"."+code)
+	}
+	return "", nil
+}
+
+// convertContent returns the type of a JSON request or response body.
+func (c *openAPIConverter) convertContent(content map[string]*openAPIMediaType, at string) (string, error) {
+	if len(content) == 0 {
+		return "", nil
+	}
+	media, ok := content["application/json"]
+	if !ok {
+		return "", unsupported(at+".content", "media types %s, only application/json is", strings.Join(sortedKeys(content), ", "))
+	}
+	return c.typeOf(media.Schema, at+".content.application/json.schema")
+}
+
+// componentName returns the name of a component referenced in the document.
+func componentName(ref, kind, at string) (string, error) {
+	prefix := "#/components/" + kind + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", unsupported(at, "reference %s, only references to components.%s are", ref, kind)
+	}
+	return strings.TrimPrefix(ref, prefix), nil
+}
+
+// checkName reports the names kept as they are which saas-y does not accept, i.e. which
+// are not snake case.
+func checkName(name, kind, at string) error {
+	if model.ValidateName(name, kind+" name") != nil {
+		return unsupported(at, "%s name %s, saas-y names are snake case", kind, name)
+	}
+	return nil
+}
+
+func unsupported(at, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: unsupported %s", at, fmt.Sprintf(format, args...))
+}
+
+// sortedKeys returns the keys of a map with string keys, sorted for a deterministic conversion.
+func sortedKeys(m interface{}) (keys []string) {
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return
+}
+
+// toSnakeCase converts camel case, kebab case or space separated names to snake case,
+// e.g. getUserByID to get_user_by_id.
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+
+	words := strings.FieldsFunc(b.String(), func(r rune) bool { return r == '_' })
+	return strings.Join(words, "_")
+}
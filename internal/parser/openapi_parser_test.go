@@ -0,0 +1,174 @@
+package parser_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/internal/model"
+	"github.com/popescu-af/saas-y/internal/parser"
+	saasytesting "github.com/popescu-af/saas-y/internal/testing"
+)
+
+var openAPIDocument = `
+{
+    "openapi": "3.0.3",
+    "info": {"title": "User Service", "version": "1.0.0"},
+    "paths": {
+        "/users/{userId}": {
+            "parameters": [
+                {"name": "userId", "in": "path", "required": true, "schema": {"type": "string", "format": "uuid"}},
+                {"$ref": "#/components/parameters/RequestID"}
+            ],
+            "get": {
+                "operationId": "getUser",
+                "parameters": [{"name": "fields", "in": "query", "schema": {"type": "array", "items": {"type": "string"}}}],
+                "responses": {
+                    "404": {"description": "not found"},
+                    "200": {"$ref": "#/components/responses/User"}
+                }
+            },
+            "patch": {
+                "operationId": "updateUser",
+                "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}},
+                "responses": {"204": {"description": "updated"}}
+            }
+        },
+        "/users": {
+            "get": {
+                "parameters": [
+                    {"name": "status", "in": "query", "required": true, "schema": {"$ref": "#/components/schemas/UserStatus"}},
+                    {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+                ],
+                "responses": {
+                    "200": {"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/User"}}}}}
+                }
+            }
+        }
+    },
+    "components": {
+        "schemas": {
+            "UserStatus": {"type": "string", "enum": ["active", "blocked"]},
+            "Email": {"type": "string", "pattern": "^[^@]+@[^@]+$", "maxLength": 254},
+            "User": {
+                "type": "object",
+                "required": ["name", "status", "age"],
+                "properties": {
+                    "name": {"type": "string", "minLength": 1},
+                    "status": {"$ref": "#/components/schemas/UserStatus"},
+                    "age": {"type": "integer", "minimum": 0},
+                    "email": {"$ref": "#/components/schemas/Email"},
+                    "created_at": {"type": "string", "format": "date-time"},
+                    "scores": {"type": "array", "maxItems": 10, "items": {"type": "number", "maximum": 100}},
+                    "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+                }
+            }
+        },
+        "parameters": {
+            "RequestID": {"name": "Request_ID", "in": "header", "schema": {"type": "string"}}
+        },
+        "responses": {
+            "User": {"description": "a user", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}
+        }
+    }
+}
+`
+
+func parseOpenAPI(t *testing.T, content string) (*model.Spec, error) {
+	pDoc, err := saasytesting.CreateJSONSpecFile(content, ".", "openapi*.json")
+	require.NoError(t, err)
+	defer os.Remove(pDoc)
+
+	p := &parser.OpenAPI{RepositoryURL: "example.com/example"}
+	return p.Parse(pDoc)
+}
+
+func TestOpenAPI(t *testing.T) {
+	spec, err := parseOpenAPI(t, openAPIDocument)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+
+	require.Len(t, spec.Services, 1)
+	svc := spec.Services[0]
+	require.Equal(t, "user-service", svc.Name)
+	require.Equal(t, "80", svc.Port)
+	require.Equal(t, "example.com/example/services/user-service", svc.RepositoryURL)
+
+	require.Equal(t, []model.Enum{{Name: "user_status", Values: []string{"active", "blocked"}}}, svc.Enums)
+
+	one, zero, ten, hundred, maxEmail := 1, 0.0, 10, 100.0, 254
+	require.Equal(t, []model.Struct{{
+		Name: "user",
+		Fields: []model.Variable{
+			{Name: "name", Type: "string", Required: true, MinLength: &one},
+			{Name: "status", Type: "user_status", Required: true},
+			{Name: "age", Type: "int", Min: &zero},
+			{Name: "email", Type: "string", Optional: true, MaxLength: &maxEmail, Pattern: "^[^@]+@[^@]+$"},
+			{Name: "created_at", Type: "time", Optional: true},
+			{Name: "scores", Type: "[]float", Optional: true, MaxLength: &ten, Max: &hundred},
+			{Name: "labels", Type: "map<string,string>", Optional: true},
+		},
+	}}, svc.Structs)
+
+	require.Equal(t, []model.API{
+		{
+			Path: "/users",
+			Methods: map[string]model.Method{
+				"get_users": {
+					Type: model.GET,
+					QueryParams: []model.Variable{
+						{Name: "status", Type: "user_status", Required: true},
+						{Name: "limit", Type: "int"},
+					},
+					ReturnType: "[]user",
+				},
+			},
+		},
+		{
+			Path: "/users/{userId:uuid}",
+			Methods: map[string]model.Method{
+				"get_user": {
+					Type:         model.GET,
+					HeaderParams: []model.Variable{{Name: "request_id", Type: "string"}},
+					QueryParams:  []model.Variable{{Name: "fields", Type: "[]string"}},
+					ReturnType:   "user",
+				},
+				"update_user": {
+					Type:         model.PATCH,
+					HeaderParams: []model.Variable{{Name: "request_id", Type: "string"}},
+					InputType:    "user",
+				},
+			},
+		},
+	}, svc.API)
+}
+
+func TestOpenAPIUnsupported(t *testing.T) {
+	tests := []struct {
+		from, to string
+		err      string
+	}{
+		{`"openapi": "3.0.3"`, `"openapi": "2.0"`, `unsupported OpenAPI version "2.0"`},
+		{`"patch": {`, `"put": {`, `paths./users/{userId}.put: unsupported operation type PUT`},
+		{`"in": "query", "schema": {"type": "integer"}`, `"in": "cookie", "schema": {"type": "integer"}`, `paths./users.get.parameters.limit: unsupported cookie parameter`},
+		{`"schema": {"type": "integer"}}`, `"schema": {"oneOf": [{"type": "integer"}]}}`, `paths./users.get.parameters.limit: unsupported keyword oneOf`},
+		{`"minimum": 0`, `"exclusiveMinimum": true`, `components.schemas.User.properties.age: unsupported keyword exclusiveMinimum`},
+		{`{"type": "string", "minLength": 1}`, `{"type": "string", "enum": ["a"]}`, `components.schemas.User.properties.name: unsupported inline enum`},
+		{`"additionalProperties": {"type": "string"}`, `"additionalProperties": true`, `components.schemas.User.properties.labels: unsupported free-form object`},
+		{`{"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}`, `{"application/xml": {}}}`, `paths./users/{userId}.patch.requestBody.content: unsupported media types application/xml`},
+		{`"#/components/schemas/UserStatus"}}`, `"other.json#/UserStatus"}}`, `unsupported reference other.json#/UserStatus`},
+		{`"/users/{userId}": {`, `"/users/{userId}/{other}": {`, `undeclared path parameter other`},
+		{`"name": "Request_ID"`, `"name": "X-Request-ID"`, `unsupported header name X-Request-ID`},
+		{`"created_at": {`, `"createdAt": {`, `components.schemas.User.properties.createdAt: unsupported property name createdAt, saas-y names are snake case`},
+		{`{"name": "limit", "in": "query"`, `{"name": "pageSize", "in": "query"`, `paths./users.get.parameters.pageSize: unsupported query parameter name pageSize, saas-y names are snake case`},
+	}
+
+	for _, tt := range tests {
+		require.Contains(t, openAPIDocument, tt.from)
+		_, err := parseOpenAPI(t, strings.Replace(openAPIDocument, tt.from, tt.to, 1))
+		require.Error(t, err, tt.to)
+		require.Contains(t, err.Error(), tt.err)
+	}
+}
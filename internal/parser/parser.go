@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
 	"github.com/popescu-af/saas-y/internal/model"
 )
 
@@ -8,3 +12,14 @@ import (
 type Abstract interface {
 	Parse(filename string) (*model.Spec, error)
 }
+
+// ForFile returns the parser for the given spec file, chosen by its extension.
+func ForFile(filename string) (Abstract, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return &JSON{}, nil
+	case ".yaml", ".yml":
+		return &YAML{}, nil
+	}
+	return nil, fmt.Errorf("unsupported spec file %s, expected a .json, .yaml or .yml file", filename)
+}
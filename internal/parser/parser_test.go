@@ -0,0 +1,291 @@
+package parser_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/internal/model"
+	"github.com/popescu-af/saas-y/internal/parser"
+	saasytesting "github.com/popescu-af/saas-y/internal/testing"
+)
+
+var jsonSpec = `
+{
+    "repository_url": "example.com/example",
+    "services": [
+        {
+            "name": "foo-service",
+            "port": "80",
+            "api": [
+                {
+                    "path": "/users/{id:uuid}",
+                    "methods": {
+                        "get_user": {
+                            "type": "GET",
+                            "header_params": [{"name": "request_id", "type": "string", "required": true}],
+                            "return_type": "user"
+                        },
+                        "delete_user": {
+                            "type": "DELETE",
+                            "header_params": [{"name": "request_id", "type": "string", "required": true}]
+                        }
+                    }
+                }
+            ],
+            "structs": [
+                {
+                    "name": "user",
+                    "fields": [
+                        {"name": "name", "type": "string", "required": true, "max_length": 64},
+                        {"name": "age", "type": "int", "optional": true, "min": 0}
+                    ]
+                }
+            ],
+            "env": [{"name": "retries", "type": "int", "value": "3"}]
+        }
+    ]
+}
+`
+
+var yamlSpec = `
+repository_url: example.com/example
+
+# reusable snippets, ignored by saas-y
+x-request-id: &request_id
+  name: request_id
+  type: string
+  required: true
+
+services:
+  - name: foo-service
+    port: 80
+    api:
+      - path: /users/{id:uuid}
+        methods:
+          # fetches a single user
+          get_user:
+            type: GET
+            header_params: [*request_id]
+            return_type: user
+          delete_user:
+            type: DELETE
+            header_params:
+              - <<: *request_id
+    structs:
+      - name: user
+        fields:
+          - {name: name, type: string, required: true, max_length: 64}
+          - {name: age, type: int, optional: true, min: 0}
+    env:
+      - name: retries
+        type: int
+        value: 3
+`
+
+func parseSpec(t *testing.T, content, pattern string) *model.Spec {
+	pSpec, err := saasytesting.CreateJSONSpecFile(content, ".", pattern)
+	require.NoError(t, err)
+	defer os.Remove(pSpec)
+
+	p, err := parser.ForFile(pSpec)
+	require.NoError(t, err)
+
+	spec, err := p.Parse(pSpec)
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+
+	// the source file names and positions differ between formats
+	spec.Source, spec.Positions = "", nil
+	for i := range spec.Services {
+		spec.Services[i].Source = ""
+	}
+	return spec
+}
+
+func TestYAMLMatchesJSON(t *testing.T) {
+	fromJSON := parseSpec(t, jsonSpec, "spec*.json")
+	require.Equal(t, fromJSON, parseSpec(t, yamlSpec, "spec*.yaml"))
+	require.Equal(t, fromJSON, parseSpec(t, yamlSpec, "spec*.yml"))
+}
+
+func TestParserForFile(t *testing.T) {
+	_, err := parser.ForFile("spec.toml")
+	require.Error(t, err)
+
+	p, err := parser.ForFile("spec.YAML")
+	require.NoError(t, err)
+	require.IsType(t, &parser.YAML{}, p)
+}
+
+func writeSpecFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "saas-y-includes")
+	require.NoError(t, err)
+
+	for name, content := range files {
+		name = filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0755))
+		require.NoError(t, ioutil.WriteFile(name, []byte(content), 0644))
+	}
+	return dir
+}
+
+func parseIncludes(t *testing.T, files map[string]string) (*model.Spec, string, error) {
+	dir := writeSpecFiles(t, files)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	root := filepath.Join(dir, "spec.json")
+	spec, err := (&parser.JSON{}).Parse(root)
+	return spec, dir, err
+}
+
+var rootSpec = `
+{
+    "repository_url": "example.com/example",
+    "domain": "example.com",
+    "include": ["services/*"],
+    "subdomains": [{"name": "api", "paths": [{"value": "/foo", "endpoint": "foo"}]}],
+    "services": [{"name": "foo", "port": "80"}]
+}
+`
+
+func TestIncludes(t *testing.T) {
+	spec, dir, err := parseIncludes(t, map[string]string{
+		"spec.json": rootSpec,
+		"services/bar.json": `{
+			"subdomains": [{"name": "api", "paths": [{"value": "/bar", "endpoint": "bar"}]}],
+			"services": [{"name": "bar", "port": "80"}],
+			"structs": [{"name": "address", "fields": [{"name": "street", "type": "string"}]}]
+		}`,
+		"services/baz.yaml": "include: [../shared/*.yml]\nexternal_services: [{name: baz, repository_url: example.com/baz, image_url: localhost:5000/baz:latest, port: 80}]\n",
+		"shared/enums.yml":  "enums: [{name: status, values: [active, inactive]}]\n",
+	})
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate())
+
+	require.Equal(t, "example.com", spec.Domain)
+	require.Len(t, spec.Services, 2)
+	require.Equal(t, "example.com/example/services/bar", spec.Services[1].RepositoryURL)
+	require.Equal(t, filepath.Join(dir, "services/bar.json")+", services[0]", spec.Services[1].Source)
+	require.Len(t, spec.ExternalServices, 1)
+	require.Len(t, spec.Structs, 1)
+	require.Len(t, spec.Enums, 1)
+	require.Equal(t, filepath.Join(dir, "shared/enums.yml")+", enums[0]", spec.Enums[0].Source)
+
+	require.Len(t, spec.Subdomains, 1)
+	require.Len(t, spec.Subdomains[0].Paths, 2)
+	require.Equal(t, filepath.Join(dir, "services/bar.json")+", subdomains[0].paths[0]", spec.Subdomains[0].Paths[1].Source)
+}
+
+func TestIncludeErrors(t *testing.T) {
+	_, dir, err := parseIncludes(t, map[string]string{
+		"spec.json":         rootSpec,
+		"services/foo.json": `{"services": [{"name": "foo", "port": "80"}]}`,
+	})
+	require.EqualError(t, err, filepath.Join(dir, "services/foo.json")+", services[0]: duplicate service foo, already defined at "+filepath.Join(dir, "spec.json")+", services[0]")
+
+	_, dir, err = parseIncludes(t, map[string]string{
+		"spec.json":         rootSpec,
+		"services/foo.json": `{"subdomains": [{"name": "api", "paths": [{"value": "/foo", "endpoint": "foo"}]}]}`,
+	})
+	require.EqualError(t, err, filepath.Join(dir, "services/foo.json")+", subdomains[0].paths[0]: duplicate path /foo of subdomain api, already defined at "+filepath.Join(dir, "spec.json")+", subdomains[0].paths[0]")
+
+	_, _, err = parseIncludes(t, map[string]string{
+		"spec.json":         rootSpec,
+		"services/foo.json": `{"include": ["../spec.json"]}`,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is already included by")
+
+	_, _, err = parseIncludes(t, map[string]string{
+		"spec.json": rootSpec,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "include pattern services/* matches no files")
+
+	_, _, err = parseIncludes(t, map[string]string{
+		"spec.json":         rootSpec,
+		"services/foo.json": `{"repository_url": "example.com/other"}`,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only allowed in the root spec file")
+
+	_, _, err = parseIncludes(t, map[string]string{
+		"spec.json":         rootSpec,
+		"services/foo.toml": `services = []`,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported spec file")
+
+	spec, dir, err := parseIncludes(t, map[string]string{
+		"spec.json":         rootSpec,
+		"services/bar.json": `{"services": [{"name": "Bar", "port": "80"}]}`,
+	})
+	require.NoError(t, err)
+	err = spec.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), filepath.Join(dir, "services/bar.json")+":1:16: services[0].name: ")
+}
+
+func TestValidationErrorPositions(t *testing.T) {
+	dir := writeSpecFiles(t, map[string]string{
+		"spec.json": `{
+    "repository_url": "example.com/example",
+    "services": [
+        {
+            "name": "foo",
+            "port": "http",
+            "api": [
+                {
+                    "path": "/users",
+                    "methods": {
+                        "get_user": {"type": "GET", "return_type": "unknown"}
+                    }
+                }
+            ]
+        }
+    ]
+}
+`,
+		"spec.yaml": `
+repository_url: example.com/example
+defaults: &defaults
+  port: "80"
+  dependencies: [nowhere]
+services:
+  - <<: *defaults
+    name: foo
+    structs:
+      - name: user
+        fields:
+          - {name: age, type: int, min_length: 1}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	validationErrors := func(filename string) model.ValidationErrors {
+		p, err := parser.ForFile(filename)
+		require.NoError(t, err)
+		spec, err := p.Parse(filename)
+		require.NoError(t, err)
+
+		err = spec.Validate()
+		require.IsType(t, model.ValidationErrors{}, err)
+		return err.(model.ValidationErrors)
+	}
+
+	jsonFile := filepath.Join(dir, "spec.json")
+	require.Equal(t, model.ValidationErrors{
+		{File: jsonFile, Line: 6, Column: 13, Path: "services[0].port", Message: "invalid port value http"},
+		{File: jsonFile, Line: 11, Column: 53, Path: "services[0].api[0].methods.get_user.return_type", Message: "unknown type unknown"},
+	}, validationErrors(jsonFile))
+
+	yamlFile := filepath.Join(dir, "spec.yaml")
+	require.Equal(t, model.ValidationErrors{
+		{File: yamlFile, Line: 5, Column: 18, Path: "services[0].dependencies[0]", Message: "unknown dependency nowhere"},
+		{File: yamlFile, Line: 12, Column: 36, Path: "services[0].structs[0].fields[0].min_length", Message: "min_length/max_length are only allowed for strings, bytes, arrays and maps, variable age is int"},
+	}, validationErrors(yamlFile))
+}
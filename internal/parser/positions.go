@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/popescu-af/saas-y/internal/model"
+)
+
+// positions map the paths of the values of a spec file, e.g. services[0].api[1].path,
+// to where they are in the file. Object members are found at their keys.
+type positions map[string]model.Position
+
+func memberPath(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+func elementPath(parent string, i int) string {
+	return fmt.Sprintf("%s[%d]", parent, i)
+}
+
+// jsonPositions finds the positions of the values of a JSON document.
+func jsonPositions(b []byte) (positions, error) {
+	p := make(positions)
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	// the decoder stops right after the previous token, the next value
+	// starts after the following whitespace and separators
+	next := func() model.Position {
+		offset := int(dec.InputOffset())
+		for offset < len(b) && bytes.IndexByte([]byte(" \t\r\n,:"), b[offset]) >= 0 {
+			offset++
+		}
+		return offsetPosition(b, offset)
+	}
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t {
+		case json.Delim('{'):
+			for dec.More() {
+				pos := next()
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				member := memberPath(path, key.(string))
+				p[member] = pos
+				if err = walk(member); err != nil {
+					return err
+				}
+			}
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				element := elementPath(path, i)
+				p[element] = next()
+				if err = walk(element); err != nil {
+					return err
+				}
+			}
+		default:
+			return nil
+		}
+
+		// closing delimiter
+		_, err = dec.Token()
+		return err
+	}
+
+	return p, walk("")
+}
+
+// offsetPosition returns the line and column of a byte offset, counting columns in bytes.
+func offsetPosition(b []byte, offset int) model.Position {
+	line := bytes.Count(b[:offset], []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(b[:offset], '\n')
+	return model.Position{Line: line, Column: column}
+}
+
+// yamlPositions finds the positions of the values of a YAML document.
+// Values merged in from anchored mappings are found where they are anchored.
+func yamlPositions(b []byte) (positions, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	p := make(positions)
+	walkYAML(p, "", &doc)
+	return p, nil
+}
+
+func walkYAML(p positions, path string, n *yaml.Node) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		for _, c := range n.Content {
+			walkYAML(p, path, c)
+		}
+	case yaml.AliasNode:
+		walkYAML(p, path, n.Alias)
+	case yaml.SequenceNode:
+		for i, c := range n.Content {
+			element := elementPath(path, i)
+			p[element] = model.Position{Line: c.Line, Column: c.Column}
+			walkYAML(p, element, c)
+		}
+	case yaml.MappingNode:
+		merged := make(positions)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i], n.Content[i+1]
+			if key.Value != "<<" {
+				member := memberPath(path, key.Value)
+				p[member] = model.Position{Line: key.Line, Column: key.Column}
+				walkYAML(p, member, value)
+				continue
+			}
+
+			// the merge key takes a mapping or a sequence of mappings
+			if value.Kind == yaml.SequenceNode {
+				for _, c := range value.Content {
+					walkYAML(merged, path, c)
+				}
+			} else {
+				walkYAML(merged, path, value)
+			}
+		}
+
+		// explicit members override the merged ones
+		for k, v := range merged {
+			if _, ok := p[k]; !ok {
+				p[k] = v
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/popescu-af/saas-y/internal/model"
+)
+
+// A YAML parses the saas-y spec from a YAML file.
+// Comments are ignored and anchors, aliases and merge keys are resolved; like in
+// JSON, unknown keys are ignored, so they can hold anchored snippets to be reused.
+type YAML struct {
+}
+
+// Parse does the actual parsing, creating the Spec struct from a YAML file
+// and the files it includes.
+func (y *YAML) Parse(filename string) (spec *model.Spec, err error) {
+	spec, err = parseWithIncludes(filename, y)
+	if err != nil {
+		return nil, err
+	}
+
+	spec.GenerateAdditionalInformation()
+	return
+}
+
+func (y *YAML) decode(b []byte, spec *model.Spec) error {
+	err := yaml.NewDecoder(bytes.NewBuffer(b)).Decode(spec)
+	if err == io.EOF {
+		// an empty document is an empty spec
+		return nil
+	}
+	return err
+}
+
+func (y *YAML) positions(b []byte) (positions, error) {
+	return yamlPositions(b)
+}
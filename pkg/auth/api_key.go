@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+)
+
+// DefaultAPIKeyHeader is the header holding the API keys, unless configured otherwise.
+const DefaultAPIKeyHeader = "X-API-Key"
+
+type apiKeyVerifier struct {
+	header string
+	keys   map[string]string
+}
+
+// NewAPIKeyVerifier creates a verifier of the API keys found in the given header,
+// DefaultAPIKeyHeader if empty. The keys are given by name, the name of the key
+// of a request becoming the subject of its principal.
+func NewAPIKeyVerifier(header string, keys map[string]string) Verifier {
+	if header == "" {
+		header = DefaultAPIKeyHeader
+	}
+	return &apiKeyVerifier{header: header, keys: keys}
+}
+
+func (v *apiKeyVerifier) Verify(r *http.Request) (*Principal, error) {
+	key := r.Header.Get(v.header)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	// all keys are compared, in constant time, not to tell how close a guess is
+	var subject string
+	found := false
+	for name, k := range v.keys {
+		if k != "" && subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+			subject, found = name, true
+		}
+	}
+	if !found {
+		return nil, errors.New("invalid API key")
+	}
+	return &Principal{Subject: subject, Credentials: key}, nil
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Principal is the authenticated caller of a method.
+type Principal struct {
+	Subject     string                 // name of the API key or sub claim of the JWT
+	Claims      map[string]interface{} // claims of the JWT, nil for API keys
+	Credentials string                 // API key or JWT, forwarded by the clients of the services
+}
+
+// Verifier verifies the credentials of the requests.
+type Verifier interface {
+	// Verify returns the principal authenticated by the credentials of the request,
+	// or ErrNoCredentials if the request has none.
+	Verify(r *http.Request) (*Principal, error)
+}
+
+// ErrNoCredentials is returned for the requests without credentials.
+var ErrNoCredentials = errors.New("missing credentials")
+
+type principalKey struct{}
+
+// NewContext returns a copy of the context holding the principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal held by the context, if any, i.e. the caller
+// of the method being served if the method is authenticated.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
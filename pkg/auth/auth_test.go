@@ -0,0 +1,139 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/pkg/auth"
+)
+
+func request(header, value string) *http.Request {
+	r, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	if header != "" {
+		r.Header.Set(header, value)
+	}
+	return r
+}
+
+func TestAPIKeyVerifier(t *testing.T) {
+	v := auth.NewAPIKeyVerifier("", map[string]string{"billing": "secret-1", "reports": "secret-2"})
+
+	p, err := v.Verify(request(auth.DefaultAPIKeyHeader, "secret-2"))
+	require.NoError(t, err)
+	require.Equal(t, &auth.Principal{Subject: "reports", Credentials: "secret-2"}, p)
+
+	_, err = v.Verify(request(auth.DefaultAPIKeyHeader, "secret-3"))
+	require.Error(t, err)
+	require.NotEqual(t, auth.ErrNoCredentials, err)
+
+	_, err = v.Verify(request("", ""))
+	require.Equal(t, auth.ErrNoCredentials, err)
+
+	p, err = auth.NewAPIKeyVerifier("X-Key", map[string]string{"billing": "secret-1"}).Verify(request("X-Key", "secret-1"))
+	require.NoError(t, err)
+	require.Equal(t, "billing", p.Subject)
+}
+
+func TestHMACJWT(t *testing.T) {
+	v, err := auth.NewJWTVerifier("HS256", "secret")
+	require.NoError(t, err)
+
+	token, err := auth.SignJWT("HS256", []byte("secret"), map[string]interface{}{"sub": "ted", "exp": time.Now().Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	p, err := v.Verify(request("Authorization", "Bearer "+token))
+	require.NoError(t, err)
+	require.Equal(t, "ted", p.Subject)
+	require.Equal(t, token, p.Credentials)
+	require.Equal(t, "ted", p.Claims["sub"])
+
+	tests := []struct {
+		algorithm string
+		key       interface{}
+		claims    map[string]interface{}
+	}{
+		{"HS256", []byte("other secret"), map[string]interface{}{"sub": "ted"}},
+		{"HS512", []byte("secret"), map[string]interface{}{"sub": "ted"}},
+		{"HS256", []byte("secret"), map[string]interface{}{"sub": "ted", "exp": time.Now().Add(-time.Minute).Unix()}},
+		{"HS256", []byte("secret"), map[string]interface{}{"sub": "ted", "nbf": time.Now().Add(time.Minute).Unix()}},
+		{"HS256", []byte("secret"), map[string]interface{}{"sub": "ted", "exp": "never"}},
+		{"HS256", []byte("secret"), map[string]interface{}{"sub": "ted", "nbf": nil}},
+	}
+	for _, tt := range tests {
+		token, err := auth.SignJWT(tt.algorithm, tt.key, tt.claims)
+		require.NoError(t, err)
+		_, err = v.Verify(request("Authorization", "Bearer "+token))
+		require.Error(t, err)
+	}
+
+	_, err = v.Verify(request("Authorization", "Basic dGVkOnNlY3JldA=="))
+	require.Error(t, err)
+	_, err = v.Verify(request("Authorization", "Bearer not.a.token"))
+	require.Error(t, err)
+	_, err = v.Verify(request("", ""))
+	require.Equal(t, auth.ErrNoCredentials, err)
+}
+
+func TestRSAJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	v, err := auth.NewJWTVerifier("RS256", publicKey)
+	require.NoError(t, err)
+
+	token, err := auth.SignJWT("RS256", key, map[string]interface{}{"sub": "ted", "role": "admin"})
+	require.NoError(t, err)
+	p, err := v.Verify(request("Authorization", "Bearer "+token))
+	require.NoError(t, err)
+	require.Equal(t, "ted", p.Subject)
+	require.Equal(t, "admin", p.Claims["role"])
+
+	// PKCS #1 keys are accepted too
+	pkcs1 := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}))
+	v, err = auth.NewJWTVerifier("RS256", pkcs1)
+	require.NoError(t, err)
+	_, err = v.Verify(request("Authorization", "Bearer "+token))
+	require.NoError(t, err)
+
+	// the public key used as an HMAC secret, to forge tokens, is rejected
+	forged, err := auth.SignJWT("HS256", []byte(publicKey), map[string]interface{}{"sub": "mallory"})
+	require.NoError(t, err)
+	_, err = v.Verify(request("Authorization", "Bearer "+forged))
+	require.Error(t, err)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	token, err = auth.SignJWT("RS256", other, map[string]interface{}{"sub": "ted"})
+	require.NoError(t, err)
+	_, err = v.Verify(request("Authorization", "Bearer "+token))
+	require.Error(t, err)
+}
+
+func TestNewJWTVerifier(t *testing.T) {
+	_, err := auth.NewJWTVerifier("none", "secret")
+	require.Error(t, err)
+	_, err = auth.NewJWTVerifier("HS256", "")
+	require.Error(t, err)
+	_, err = auth.NewJWTVerifier("RS256", "not a PEM key")
+	require.Error(t, err)
+}
+
+func TestContext(t *testing.T) {
+	_, ok := auth.FromContext(context.Background())
+	require.False(t, ok)
+
+	p := &auth.Principal{Subject: "ted"}
+	actual, ok := auth.FromContext(auth.NewContext(context.Background(), p))
+	require.True(t, ok)
+	require.Equal(t, p, actual)
+}
@@ -0,0 +1,247 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	// the hash functions of the algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// hashes are the hash functions of the supported JWT algorithms, HMAC and RSA ones.
+var hashes = map[string]crypto.Hash{
+	"HS256": crypto.SHA256,
+	"HS384": crypto.SHA384,
+	"HS512": crypto.SHA512,
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+type jwtVerifier struct {
+	algorithm string
+	key       interface{}
+}
+
+// NewJWTVerifier creates a verifier of the JWTs sent as bearer tokens in the Authorization
+// header, signed with the given algorithm. The key is the secret for the HMAC algorithms
+// (HS256, HS384 and HS512) and the PEM encoded public key for the RSA ones (RS256, RS384
+// and RS512). Only the tokens signed with the given algorithm are accepted.
+func NewJWTVerifier(algorithm, key string) (Verifier, error) {
+	if _, ok := hashes[algorithm]; !ok {
+		return nil, fmt.Errorf("unsupported JWT algorithm %s", algorithm)
+	}
+	if key == "" {
+		return nil, errors.New("missing JWT key")
+	}
+
+	if strings.HasPrefix(algorithm, "HS") {
+		return &jwtVerifier{algorithm: algorithm, key: []byte(key)}, nil
+	}
+	publicKey, err := ParseRSAPublicKey([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return &jwtVerifier{algorithm: algorithm, key: publicKey}, nil
+}
+
+// ParseRSAPublicKey parses a PEM encoded RSA public key, in PKIX or PKCS #1 form.
+func ParseRSAPublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+	return publicKey, nil
+}
+
+func (v *jwtVerifier) Verify(r *http.Request) (*Principal, error) {
+	header := r.Header.Get("Authorization")
+	if header == "" {
+		return nil, ErrNoCredentials
+	}
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return nil, errors.New("invalid authorization, a bearer token is expected")
+	}
+
+	token := strings.TrimSpace(header[7:])
+	claims, err := ParseJWT(token, v.algorithm, v.key)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, _ := claims["sub"].(string)
+	return &Principal{Subject: subject, Claims: claims, Credentials: token}, nil
+}
+
+// ParseJWT verifies a JWT signed with the given algorithm, with the key being the secret
+// for the HMAC algorithms and the *rsa.PublicKey for the RSA ones, and returns its claims.
+// Expired tokens and tokens not valid yet, as told by their exp and nbf claims, are rejected,
+// as are tokens whose exp or nbf claim is not a number.
+func ParseJWT(token, algorithm string, key interface{}) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid token")
+	}
+
+	var header struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Algorithm != algorithm {
+		return nil, fmt.Errorf("invalid token, signed with %s instead of %s", header.Algorithm, algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("invalid token signature")
+	}
+	if err := verifySignature(parts[0]+"."+parts[1], signature, algorithm, key); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	now := float64(time.Now().Unix())
+	exp, err := timeClaim(claims, "exp")
+	if err != nil {
+		return nil, err
+	}
+	if exp != nil && now >= *exp {
+		return nil, errors.New("token expired")
+	}
+	nbf, err := timeClaim(claims, "nbf")
+	if err != nil {
+		return nil, err
+	}
+	if nbf != nil && now < *nbf {
+		return nil, errors.New("token not valid yet")
+	}
+	return claims, nil
+}
+
+// timeClaim returns the named time claim, in seconds since the epoch, or nil if missing.
+// A claim which is not a number is an error, not to accept a token regardless of it.
+func timeClaim(claims map[string]interface{}, name string) (*float64, error) {
+	value, ok := claims[name]
+	if !ok {
+		return nil, nil
+	}
+	t, ok := value.(float64)
+	if !ok {
+		return nil, fmt.Errorf("invalid token, the %s claim is not a number", name)
+	}
+	return &t, nil
+}
+
+// SignJWT creates a JWT with the given claims, signed with the given algorithm, with the
+// key being the secret for the HMAC algorithms and the *rsa.PrivateKey for the RSA ones.
+func SignJWT(algorithm string, key interface{}, claims map[string]interface{}) (string, error) {
+	hash, ok := hashes[algorithm]
+	if !ok {
+		return "", fmt.Errorf("unsupported JWT algorithm %s", algorithm)
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": algorithm, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch key := key.(type) {
+	case []byte:
+		if !strings.HasPrefix(algorithm, "HS") {
+			return "", fmt.Errorf("a secret cannot sign with %s", algorithm)
+		}
+		mac := hmac.New(hash.New, key)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		if !strings.HasPrefix(algorithm, "RS") {
+			return "", fmt.Errorf("an RSA key cannot sign with %s", algorithm)
+		}
+		h := hash.New()
+		h.Write([]byte(signed))
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, key, hash, h.Sum(nil)); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("invalid key of type %T", key)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func verifySignature(signed string, signature []byte, algorithm string, key interface{}) error {
+	hash, ok := hashes[algorithm]
+	if !ok {
+		return fmt.Errorf("unsupported JWT algorithm %s", algorithm)
+	}
+
+	switch key := key.(type) {
+	case []byte:
+		if !strings.HasPrefix(algorithm, "HS") {
+			break
+		}
+		mac := hmac.New(hash.New, key)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(algorithm, "RS") {
+			break
+		}
+		h := hash.New()
+		h.Write([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), signature); err != nil {
+			return errors.New("invalid token signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid key of type %T for %s", key, algorithm)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.New("invalid token encoding")
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.New("invalid token encoding")
+	}
+	return nil
+}
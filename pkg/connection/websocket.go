@@ -1,8 +1,11 @@
 package connection
 
 import (
+	"context"
+	"crypto/tls"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gorilla/websocket"
 
@@ -36,9 +39,34 @@ func newWebSocketChannel(c *websocket.Conn) Channel {
 }
 
 // NewWebSocketClient creates a new websocket connection and a full-duplex
-// connection on top of it.
-func NewWebSocketClient(url url.URL, listener ChannelListener) (*FullDuplex, error) {
-	c, _, err := websocket.DefaultDialer.Dial(url.String(), nil)
+// connection on top of it. The context bounds the opening of the connection.
+//
+// Deprecated: use NewWebSocketClientWithOptions.
+func NewWebSocketClient(ctx context.Context, url url.URL, listener ChannelListener) (*FullDuplex, error) {
+	return NewWebSocketClientWithOptions(ctx, url, WebSocketClientOptions{}, listener)
+}
+
+// NewWebSocketClientWithTLS does the same as NewWebSocketClient, using the given
+// TLS configuration for wss URLs, or the default one if nil.
+//
+// Deprecated: use NewWebSocketClientWithOptions, with the TLSConfig option.
+func NewWebSocketClientWithTLS(ctx context.Context, url url.URL, config *tls.Config, listener ChannelListener) (*FullDuplex, error) {
+	return NewWebSocketClientWithOptions(ctx, url, WebSocketClientOptions{TLSConfig: config}, listener)
+}
+
+// WebSocketClientOptions configures the opening of websocket client connections.
+type WebSocketClientOptions struct {
+	TLSConfig *tls.Config // configuration for wss URLs, the default one if nil
+	Header    http.Header // headers of the opening handshake request, e.g. credentials
+}
+
+// NewWebSocketClientWithOptions creates a new websocket connection, opened with the given
+// options, and a full-duplex connection on top of it. The context bounds the opening of
+// the connection.
+func NewWebSocketClientWithOptions(ctx context.Context, url url.URL, options WebSocketClientOptions, listener ChannelListener) (*FullDuplex, error) {
+	dialer := *websocket.DefaultDialer
+	dialer.TLSClientConfig = options.TLSConfig
+	c, _, err := dialer.DialContext(ctx, url.String(), options.Header)
 	if err != nil {
 		log.ErrorCtx("dial", log.Context{"error": err})
 		return nil, err
@@ -49,16 +77,47 @@ func NewWebSocketClient(url url.URL, listener ChannelListener) (*FullDuplex, err
 	return conn, nil
 }
 
-// TODO: Properly instantiate the upgrader, with the possibility of
-// customizing the origin checker function
-var upgrader = func() websocket.Upgrader {
-	upgrader := websocket.Upgrader{}
-	upgrader.CheckOrigin = func(r *http.Request) bool { return true }
-	return upgrader
-}()
+// WebSocketServerOptions configures the accepting of websocket server connections.
+type WebSocketServerOptions struct {
+	// CheckOrigin tells if a connection may be opened for the request, given its Origin header;
+	// if nil, only the same-origin requests and those without an Origin header are accepted,
+	// see CheckOrigins.
+	CheckOrigin func(r *http.Request) bool
+}
 
-// NewWebSocketServer does the same as NewWebSocketClient, but from a server point of view.
+// CheckOrigins returns an origin check accepting the requests from the given origins, e.g.
+// https://example.com, or from any if one of them is "*", besides the same-origin requests,
+// whose origin has the host of the request, and those without an Origin header, i.e. not
+// from browsers. The scheme of the origin is not checked against that of the request, which
+// is plain HTTP when TLS ends at a proxy in front of the service.
+func CheckOrigins(origins ...string) func(r *http.Request) bool {
+	allowed := make(map[string]bool, len(origins))
+	for _, o := range origins {
+		allowed[o] = true
+	}
+
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" || allowed["*"] || allowed[origin] {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+}
+
+// NewWebSocketServer does the same as NewWebSocketClientWithOptions, but from a server point of view.
+// Only the same-origin requests and those without an Origin header are accepted.
 func NewWebSocketServer(w http.ResponseWriter, r *http.Request, listener ChannelListener) (*FullDuplex, error) {
+	return NewWebSocketServerWithOptions(w, r, WebSocketServerOptions{}, listener)
+}
+
+// NewWebSocketServerWithOptions does the same as NewWebSocketServer, using the given options.
+func NewWebSocketServerWithOptions(w http.ResponseWriter, r *http.Request, options WebSocketServerOptions, listener ChannelListener) (*FullDuplex, error) {
+	upgrader := websocket.Upgrader{CheckOrigin: options.CheckOrigin}
+	if upgrader.CheckOrigin == nil {
+		upgrader.CheckOrigin = CheckOrigins()
+	}
 	c, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.ErrorCtx("upgrade", log.Context{"error": err})
@@ -0,0 +1,89 @@
+package connection_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/pkg/connection"
+)
+
+func TestCheckOrigins(t *testing.T) {
+	request := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "http://api.example.com/events", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	check := connection.CheckOrigins("https://app.example.com", "http://localhost:3000")
+	require.True(t, check(request("https://app.example.com")))
+	require.True(t, check(request("http://localhost:3000")))
+	require.True(t, check(request("http://api.example.com")))
+	require.True(t, check(request("https://api.example.com")))
+	require.True(t, check(request("")))
+	require.False(t, check(request("https://evil.example.com")))
+	require.False(t, check(request("http://localhost:3001")))
+
+	require.True(t, connection.CheckOrigins("*")(request("https://evil.example.com")))
+	require.False(t, connection.CheckOrigins()(request("https://app.example.com")))
+}
+
+func TestWebSocketServerOrigins(t *testing.T) {
+	options := connection.WebSocketServerOptions{CheckOrigin: connection.CheckOrigins("https://app.example.com")}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := connection.NewWebSocketServerWithOptions(w, r, options, &connection.ChannelListenerMock{})
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	dial := func(origin string) (*http.Response, error) {
+		header := http.Header{"Origin": {origin}}
+		c, response, err := websocket.DefaultDialer.Dial(strings.Replace(server.URL, "http", "ws", 1), header)
+		if err == nil {
+			c.Close()
+		}
+		return response, err
+	}
+
+	_, err := dial("https://app.example.com")
+	require.NoError(t, err)
+
+	response, err := dial("https://evil.example.com")
+	require.Error(t, err)
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func TestWebSocketServerSameOrigin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := connection.NewWebSocketServer(w, r, &connection.ChannelListenerMock{})
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	defer server.Close()
+
+	dial := func(origin string) (*http.Response, error) {
+		header := http.Header{"Origin": {origin}}
+		c, response, err := websocket.DefaultDialer.Dial(strings.Replace(server.URL, "http", "ws", 1), header)
+		if err == nil {
+			c.Close()
+		}
+		return response, err
+	}
+
+	// TLS ending at a proxy, the browser sends an https origin over a plain connection
+	_, err := dial(strings.Replace(server.URL, "http", "https", 1))
+	require.NoError(t, err)
+
+	response, err := dial("https://evil.example.com")
+	require.Error(t, err)
+	require.Equal(t, http.StatusForbidden, response.StatusCode)
+}
@@ -0,0 +1,103 @@
+package retry
+
+import (
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Policy tells how to retry the HTTP requests which failed for reasons likely to be
+// temporary, i.e. network errors and the 429, 502, 503 and 504 statuses. Only the
+// requests with idempotent methods are retried, the others being sent once.
+type Policy struct {
+	MaxAttempts    int           // attempts in total, the first one included
+	InitialBackoff time.Duration // maximum wait before the first retry
+	MaxBackoff     time.Duration // maximum wait before any retry
+	Multiplier     float64       // growth of the backoff with every retry
+}
+
+// DefaultPolicy makes 3 attempts, waiting up to 100ms and 200ms in between.
+var DefaultPolicy = Policy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// Backoff returns the time to wait before the given retry, counted from 1. It is
+// random, up to a backoff growing exponentially with the retries (full jitter),
+// which keeps the clients of a failing service from retrying all at once.
+func (p Policy) Backoff(retry int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if backoff < 1 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(backoff)))
+}
+
+// Do sends the request with the given client, retrying it as long as it fails temporarily,
+// the attempts are not used up and its context is not done. The bodies of the requests
+// are sent again with GetBody, set by http.NewRequest for the usual types of bodies.
+func (p Policy) Do(client *http.Client, request *http.Request) (*http.Response, error) {
+	if !Idempotent(request.Method) {
+		return client.Do(request)
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, err := client.Do(request)
+		if attempt >= p.MaxAttempts || !temporary(response, err) || request.Context().Err() != nil {
+			return response, err
+		}
+		if request.Body != nil && request.GetBody == nil {
+			return response, err
+		}
+
+		if response != nil {
+			// drained, for the connection to be reused
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		select {
+		case <-time.After(p.Backoff(attempt)):
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		}
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			request = request.WithContext(request.Context())
+			request.Body = body
+		}
+	}
+}
+
+// Idempotent tells if the requests with the given method can be sent more than once.
+func Idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func temporary(response *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package retry_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/pkg/retry"
+)
+
+var fastPolicy = retry.Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, Multiplier: 2}
+
+// failingServer responds with 503 to the first given number of requests and then echoes their bodies.
+func failingServer(failures int32, attempts *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(attempts, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		w.Write(b)
+	}))
+}
+
+func TestBackoff(t *testing.T) {
+	p := retry.Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+	for i := 0; i < 100; i++ {
+		require.True(t, p.Backoff(1) < 100*time.Millisecond)
+		require.True(t, p.Backoff(2) < 200*time.Millisecond)
+		require.True(t, p.Backoff(5) < 300*time.Millisecond)
+		require.True(t, p.Backoff(5) >= 0)
+	}
+	require.Equal(t, time.Duration(0), retry.Policy{}.Backoff(1))
+}
+
+func TestDoRetriesIdempotentRequests(t *testing.T) {
+	var attempts int32
+	s := failingServer(2, &attempts)
+	defer s.Close()
+
+	request, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	require.NoError(t, err)
+	response, err := fastPolicy.Do(http.DefaultClient, request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, int32(3), attempts)
+}
+
+func TestDoGivesUp(t *testing.T) {
+	var attempts int32
+	s := failingServer(5, &attempts)
+	defer s.Close()
+
+	request, err := http.NewRequest(http.MethodDelete, s.URL, strings.NewReader("body"))
+	require.NoError(t, err)
+	response, err := fastPolicy.Do(http.DefaultClient, request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	require.Equal(t, int32(3), attempts)
+}
+
+func TestDoResendsBodies(t *testing.T) {
+	var attempts int32
+	s := failingServer(1, &attempts)
+	defer s.Close()
+
+	request, err := http.NewRequest(http.MethodPut, s.URL, strings.NewReader("body"))
+	require.NoError(t, err)
+	response, err := fastPolicy.Do(http.DefaultClient, request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+
+	b, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Equal(t, "body", string(b))
+	require.Equal(t, int32(2), attempts)
+}
+
+func TestDoSendsOtherRequestsOnce(t *testing.T) {
+	var attempts int32
+	s := failingServer(1, &attempts)
+	defer s.Close()
+
+	for _, method := range []string{http.MethodPost, http.MethodPatch} {
+		atomic.StoreInt32(&attempts, 0)
+		request, err := http.NewRequest(method, s.URL, strings.NewReader("body"))
+		require.NoError(t, err)
+		response, err := fastPolicy.Do(http.DefaultClient, request)
+		require.NoError(t, err)
+		response.Body.Close()
+		require.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+		require.Equal(t, int32(1), attempts)
+	}
+}
+
+func TestDoStopsWhenTheContextIsDone(t *testing.T) {
+	var attempts int32
+	s := failingServer(5, &attempts)
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	request, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	require.NoError(t, err)
+	_, err = retry.Policy{MaxAttempts: 3, InitialBackoff: time.Hour}.Do(http.DefaultClient, request.WithContext(ctx))
+	require.Error(t, err)
+	require.True(t, attempts <= 1)
+}
@@ -1,11 +1,14 @@
 package storage
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // KeyValue is the interface for a storage holding key-value pairs.
 type KeyValue interface {
-	Get(key string) ([]byte, error)
-	Set(key string, value []byte, expiration time.Duration) error
-	Delete(key string) error
-	Ready() error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	Delete(ctx context.Context, key string) error
+	Ready(ctx context.Context) error
 }
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -17,7 +18,7 @@ type KeyValueMock struct {
 }
 
 // Get implements the method with the same name from KeyValue.
-func (k *KeyValueMock) Get(key string) ([]byte, error) {
+func (k *KeyValueMock) Get(ctx context.Context, key string) ([]byte, error) {
 	k.mutex.RLock()
 	defer k.mutex.RUnlock()
 
@@ -29,7 +30,7 @@ func (k *KeyValueMock) Get(key string) ([]byte, error) {
 }
 
 // Set implements the method with the same name from KeyValue.
-func (k *KeyValueMock) Set(key string, value []byte, expiration time.Duration) error {
+func (k *KeyValueMock) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
 	k.mutex.Lock()
 	defer k.mutex.Unlock()
 
@@ -39,7 +40,7 @@ func (k *KeyValueMock) Set(key string, value []byte, expiration time.Duration) e
 }
 
 // Delete implements the method with the same name from KeyValue.
-func (k *KeyValueMock) Delete(key string) error {
+func (k *KeyValueMock) Delete(ctx context.Context, key string) error {
 	k.mutex.Lock()
 	defer k.mutex.Unlock()
 
@@ -49,7 +50,7 @@ func (k *KeyValueMock) Delete(key string) error {
 }
 
 // Ready implements the method with the same name from KeyValue.
-func (k *KeyValueMock) Ready() error {
+func (k *KeyValueMock) Ready(ctx context.Context) error {
 	return nil
 }
 
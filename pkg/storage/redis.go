@@ -20,21 +20,21 @@ func NewRedis(options *redis.Options) *Redis {
 }
 
 // Get returns the pre-cached value for the given key.
-func (r *Redis) Get(key string) ([]byte, error) {
-	return r.client.Get(context.TODO(), key).Bytes()
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	return r.client.Get(ctx, key).Bytes()
 }
 
 // Set sets the value for the specified key in the cache.
-func (r *Redis) Set(key string, value []byte, expiration time.Duration) error {
-	return r.client.Set(context.TODO(), key, value, expiration).Err()
+func (r *Redis) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
 // Delete removes the entry for the specified key.
-func (r *Redis) Delete(key string) error {
-	return r.client.Del(context.TODO(), key).Err()
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, key).Err()
 }
 
 // Ready tells if the redis connection is ready.
-func (r *Redis) Ready() error {
-	return r.client.Ping(context.TODO()).Err()
+func (r *Redis) Ready(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
 }
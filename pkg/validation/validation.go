@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// Error is the error returned when a field breaks the constraints declared in the spec.
+type Error struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+func (e *Error) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+// Errorf creates a validation error for the given field.
+func Errorf(field, format string, args ...interface{}) *Error {
+	return &Error{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Nested prefixes the field of a validation error with the name of the
+// field containing it. Other errors are returned unchanged.
+func Nested(field string, err error) error {
+	if e, ok := err.(*Error); ok {
+		return &Error{Field: field + "." + e.Field, Reason: e.Reason}
+	}
+	return err
+}
+
+// Index returns the name of an element of an array field.
+func Index(field string, i int) string {
+	return field + "[" + strconv.Itoa(i) + "]"
+}
+
+// Key returns the name of a value of a map field.
+func Key(field string, key interface{}) string {
+	return fmt.Sprintf("%s[%v]", field, key)
+}
+
+// Min checks that a numeric value is not lower than min.
+func Min(field string, value, min float64) error {
+	if value < min {
+		return Errorf(field, "must be at least %v", min)
+	}
+	return nil
+}
+
+// Max checks that a numeric value is not greater than max.
+func Max(field string, value, max float64) error {
+	if value > max {
+		return Errorf(field, "must be at most %v", max)
+	}
+	return nil
+}
+
+// MinLength checks that a length is not lower than min.
+func MinLength(field string, length, min int) error {
+	if length < min {
+		return Errorf(field, "must have a length of at least %d", min)
+	}
+	return nil
+}
+
+// MaxLength checks that a length is not greater than max.
+func MaxLength(field string, length, max int) error {
+	if length > max {
+		return Errorf(field, "must have a length of at most %d", max)
+	}
+	return nil
+}
+
+var patterns sync.Map
+
+// Pattern checks that a string value matches the given regular expression.
+func Pattern(field, value, pattern string) error {
+	re, ok := patterns.Load(pattern)
+	if !ok {
+		re, _ = patterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	}
+	if !re.(*regexp.Regexp).MatchString(value) {
+		return Errorf(field, "must match %s", pattern)
+	}
+	return nil
+}
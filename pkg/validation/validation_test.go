@@ -0,0 +1,35 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/popescu-af/saas-y/pkg/validation"
+)
+
+func TestChecks(t *testing.T) {
+	require.NoError(t, validation.Min("age", 18, 18))
+	require.Error(t, validation.Min("age", 17.5, 18))
+	require.NoError(t, validation.Max("age", 99, 99))
+	require.Error(t, validation.Max("age", 100, 99))
+	require.NoError(t, validation.MinLength("name", 3, 3))
+	require.Error(t, validation.MinLength("name", 2, 3))
+	require.NoError(t, validation.MaxLength("name", 3, 3))
+	require.Error(t, validation.MaxLength("name", 4, 3))
+	require.NoError(t, validation.Pattern("code", "ab-12", "^[a-z]+-[0-9]+$"))
+	require.Error(t, validation.Pattern("code", "ab_12", "^[a-z]+-[0-9]+$"))
+}
+
+func TestNested(t *testing.T) {
+	err := validation.Nested(validation.Index("orders", 2), validation.Errorf("name", "is required"))
+	require.Equal(t, &validation.Error{Field: "orders[2].name", Reason: "is required"}, err)
+	require.Equal(t, "orders[2].name: is required", err.Error())
+
+	err = validation.Nested(validation.Key("labels", "env"), validation.Errorf("value", "is required"))
+	require.Equal(t, "labels[env].value: is required", err.Error())
+
+	other := errors.New("other")
+	require.Equal(t, other, validation.Nested("orders", other))
+}
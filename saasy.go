@@ -0,0 +1,9 @@
+// Package saasy holds the module file of saas-y, for the modules it generates to require it.
+package saasy
+
+import _ "embed" // for the module file
+
+// GoMod is the go.mod file of saas-y.
+//
+//go:embed go.mod
+var GoMod []byte